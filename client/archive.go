@@ -0,0 +1,211 @@
+package client
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// archiveFormat identifies a supported archive type
+type archiveFormat int
+
+const (
+	archiveUnknown archiveFormat = iota
+	archiveTar
+	archiveTarGzip
+	archiveZip
+)
+
+// ExtractArchive downloads a tar, tar.gz or zip object and extracts it into
+// destDir. The format is detected from the key's extension, falling back to
+// the object's content type. Tar archives are extracted while streaming; zip
+// archives keep their index at the end, so they are staged in a temporary
+// file first. Entries that would escape destDir are rejected and links are
+// skipped.
+func (c *Client) ExtractArchive(ctx context.Context, bucketName, objectKey, destDir string, opts ...RequestOption) error {
+	format := archiveFormatFromName(objectKey)
+	if format == archiveUnknown {
+		info, err := c.HeadObject(ctx, bucketName, objectKey, opts...)
+		if err != nil {
+			return err
+		}
+		format = archiveFormatFromContentType(info.ContentType)
+	}
+	if format == archiveUnknown {
+		return fmt.Errorf("failed to extract %s: unsupported archive format", objectKey)
+	}
+
+	body, err := c.GetObject(ctx, bucketName, objectKey, opts...)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create destination: %w", err)
+	}
+
+	switch format {
+	case archiveTarGzip:
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		defer gz.Close()
+		return extractTar(ctx, gz, destDir)
+	case archiveTar:
+		return extractTar(ctx, body, destDir)
+	default:
+		return extractZip(ctx, body, destDir)
+	}
+}
+
+// archiveFormatFromName detects the archive format from a file name
+func archiveFormatFromName(name string) archiveFormat {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return archiveTarGzip
+	case strings.HasSuffix(lower, ".tar"):
+		return archiveTar
+	case strings.HasSuffix(lower, ".zip"):
+		return archiveZip
+	}
+	return archiveUnknown
+}
+
+// archiveFormatFromContentType detects the archive format from a content type
+func archiveFormatFromContentType(contentType string) archiveFormat {
+	switch baseContentType(contentType) {
+	case "application/x-tar":
+		return archiveTar
+	case "application/gzip", "application/x-gzip", "application/x-gtar", "application/x-compressed-tar":
+		return archiveTarGzip
+	case ContentTypeZip, "application/x-zip-compressed":
+		return archiveZip
+	}
+	return archiveUnknown
+}
+
+// extractTar writes the entries of a tar stream below destDir
+func extractTar(ctx context.Context, r io.Reader, destDir string) error {
+	tr := tar.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar entry: %w", err)
+		}
+
+		target, err := archiveTarget(destDir, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr, header.FileInfo().Mode()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractZip stages a zip stream in a temporary file and extracts it below destDir
+func extractZip(ctx context.Context, r io.Reader, destDir string) error {
+	tmp, err := os.CreateTemp("", "gtm-storage-*.zip")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return fmt.Errorf("failed to download archive: %w", err)
+	}
+
+	zr, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return fmt.Errorf("failed to open zip archive: %w", err)
+	}
+
+	for _, file := range zr.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		target, err := archiveTarget(destDir, file.Name)
+		if err != nil {
+			return err
+		}
+
+		mode := file.Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+		case mode.IsRegular():
+			rc, err := file.Open()
+			if err != nil {
+				return fmt.Errorf("failed to open zip entry %s: %w", file.Name, err)
+			}
+			err = writeArchiveFile(target, rc, mode)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// archiveTarget resolves an entry name below destDir, rejecting absolute
+// paths and ".." segments that would escape it
+func archiveTarget(destDir, name string) (string, error) {
+	target := filepath.Join(destDir, filepath.FromSlash(name))
+
+	rel, err := filepath.Rel(destDir, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(name) {
+		return "", fmt.Errorf("illegal archive entry path: %s", name)
+	}
+
+	return target, nil
+}
+
+// writeArchiveFile writes one extracted file, creating parent directories
+func writeArchiveFile(target string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+
+	return file.Close()
+}
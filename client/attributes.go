@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// Object attributes that can be requested from GetObjectAttributes
+const (
+	AttributeETag         = "ETag"
+	AttributeChecksum     = "Checksum"
+	AttributeObjectParts  = "ObjectParts"
+	AttributeStorageClass = "StorageClass"
+	AttributeObjectSize   = "ObjectSize"
+)
+
+// ObjectAttributes holds the attributes returned by GetObjectAttributes.
+// Only the requested attributes are populated.
+type ObjectAttributes struct {
+	ETag         string         `xml:"ETag"`
+	Size         int64          `xml:"ObjectSize"`
+	StorageClass string         `xml:"StorageClass"`
+	Checksum     ObjectChecksum `xml:"Checksum"`
+	Parts        ObjectParts    `xml:"ObjectParts"`
+}
+
+// ObjectChecksum holds the checksums the server stored for an object
+type ObjectChecksum struct {
+	CRC32  string `xml:"ChecksumCRC32"`
+	CRC32C string `xml:"ChecksumCRC32C"`
+	SHA1   string `xml:"ChecksumSHA1"`
+	SHA256 string `xml:"ChecksumSHA256"`
+}
+
+// ObjectParts describes how a multipart object is split
+type ObjectParts struct {
+	TotalPartsCount int          `xml:"TotalPartsCount"`
+	Parts           []ObjectPart `xml:"Part"`
+}
+
+// ObjectPart describes one part of a multipart object
+type ObjectPart struct {
+	PartNumber int   `xml:"PartNumber"`
+	Size       int64 `xml:"Size"`
+}
+
+// GetObjectAttributes fetches several object attributes, such as size, ETag,
+// checksum, storage class and parts, in a single request. attrs selects the
+// Attribute* values to return; nil requests all of them.
+func (c *Client) GetObjectAttributes(ctx context.Context, bucketName, objectKey string, attrs []string, opts ...RequestOption) (*ObjectAttributes, error) {
+	if len(attrs) == 0 {
+		attrs = []string{AttributeETag, AttributeChecksum, AttributeObjectParts, AttributeStorageClass, AttributeObjectSize}
+	}
+
+	url := c.objectURL(bucketName, objectKey, newRequestOptions(opts)) + "?attributes"
+	opts = append(slices.Clip(opts), withHeader("X-Object-Attributes", strings.Join(attrs, ",")))
+
+	var result ObjectAttributes
+	if err := c.getXML(ctx, url, "get object attributes", &result, opts); err != nil {
+		return nil, err
+	}
+
+	result.ETag = strings.Trim(result.ETag, "\"")
+	return &result, nil
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// Put uploads an object to the default bucket
+func (c *Client) Put(ctx context.Context, objectKey string, reader io.Reader, filename string, opts ...RequestOption) (*UploadResult, error) {
+	return c.PutObject(ctx, "", objectKey, reader, filename, opts...)
+}
+
+// Get retrieves an object from the default bucket
+func (c *Client) Get(ctx context.Context, objectKey string, opts ...RequestOption) (io.ReadCloser, error) {
+	return c.GetObject(ctx, "", objectKey, opts...)
+}
+
+// Head retrieves object metadata from the default bucket
+func (c *Client) Head(ctx context.Context, objectKey string, opts ...RequestOption) (*ObjectInfo, error) {
+	return c.HeadObject(ctx, "", objectKey, opts...)
+}
+
+// Delete deletes an object from the default bucket
+func (c *Client) Delete(ctx context.Context, objectKey string, opts ...RequestOption) error {
+	return c.DeleteObject(ctx, "", objectKey, opts...)
+}
+
+// List lists objects in the default bucket
+func (c *Client) List(ctx context.Context, prefix string, opts ...RequestOption) ([]ObjectInfo, error) {
+	return c.ListObjects(ctx, "", prefix, opts...)
+}
+
+// BucketClient is a handle for one bucket that shares its parent client's
+// transport, auth and options
+type BucketClient struct {
+	client     *Client
+	bucketName string
+}
+
+// Bucket returns a handle whose methods operate on bucketName
+func (c *Client) Bucket(bucketName string) *BucketClient {
+	return &BucketClient{client: c, bucketName: bucketName}
+}
+
+// Name returns the bucket the handle operates on
+func (b *BucketClient) Name() string {
+	return b.bucketName
+}
+
+// Client returns the parent client, for operations without a bucket method
+func (b *BucketClient) Client() *Client {
+	return b.client
+}
+
+// Put uploads an object to the bucket
+func (b *BucketClient) Put(ctx context.Context, objectKey string, reader io.Reader, filename string, opts ...RequestOption) (*UploadResult, error) {
+	return b.client.PutObject(ctx, b.bucketName, objectKey, reader, filename, opts...)
+}
+
+// Get retrieves an object from the bucket
+func (b *BucketClient) Get(ctx context.Context, objectKey string, opts ...RequestOption) (io.ReadCloser, error) {
+	return b.client.GetObject(ctx, b.bucketName, objectKey, opts...)
+}
+
+// Head retrieves object metadata from the bucket
+func (b *BucketClient) Head(ctx context.Context, objectKey string, opts ...RequestOption) (*ObjectInfo, error) {
+	return b.client.HeadObject(ctx, b.bucketName, objectKey, opts...)
+}
+
+// Delete deletes an object from the bucket
+func (b *BucketClient) Delete(ctx context.Context, objectKey string, opts ...RequestOption) error {
+	return b.client.DeleteObject(ctx, b.bucketName, objectKey, opts...)
+}
+
+// List lists objects in the bucket
+func (b *BucketClient) List(ctx context.Context, prefix string, opts ...RequestOption) ([]ObjectInfo, error) {
+	return b.client.ListObjects(ctx, b.bucketName, prefix, opts...)
+}
+
+// createBucketIfMissing creates a bucket, treating a concurrent creation
+// (409 Conflict) as success
+func (c *Client) createBucketIfMissing(ctx context.Context, bucketName string) error {
+	err := c.MakeBucket(ctx, c.bucket(bucketName))
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusConflict {
+		return nil
+	}
+	return err
+}
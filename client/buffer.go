@@ -0,0 +1,37 @@
+package client
+
+import (
+	"io"
+	"sync"
+)
+
+// defaultCopyBufferSize matches the buffer io.Copy allocates on its own
+const defaultCopyBufferSize = 32 * 1024
+
+// bufferPool hands out reusable copy buffers of a fixed size
+type bufferPool struct {
+	pool sync.Pool
+}
+
+// newBufferPool creates a pool of size-byte buffers
+func newBufferPool(size int) *bufferPool {
+	if size <= 0 {
+		size = defaultCopyBufferSize
+	}
+
+	return &bufferPool{
+		pool: sync.Pool{
+			New: func() any {
+				buf := make([]byte, size)
+				return &buf
+			},
+		},
+	}
+}
+
+// copyBuffer copies src to dst with a pooled buffer instead of allocating one per call
+func (p *bufferPool) copyBuffer(dst io.Writer, src io.Reader) (int64, error) {
+	buf := p.pool.Get().(*[]byte)
+	defer p.pool.Put(buf)
+	return io.CopyBuffer(dst, src, *buf)
+}
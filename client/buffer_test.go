@@ -0,0 +1,54 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+)
+
+// onlyReader and onlyWriter hide ReaderFrom and WriterTo, so copies go
+// through a buffer as they do for request bodies and form files
+type onlyReader struct{ io.Reader }
+type onlyWriter struct{ io.Writer }
+
+func BenchmarkCopyBuffer(b *testing.B) {
+	data := bytes.Repeat([]byte("x"), 256*1024)
+
+	b.Run("io.Copy", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				io.Copy(onlyWriter{io.Discard}, onlyReader{bytes.NewReader(data)})
+			}
+		})
+	})
+
+	b.Run("pooled", func(b *testing.B) {
+		pool := newBufferPool(0)
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				pool.copyBuffer(onlyWriter{io.Discard}, onlyReader{bytes.NewReader(data)})
+			}
+		})
+	})
+}
+
+func BenchmarkPutObjectParallel(b *testing.B) {
+	c, _ := newTestClient(b)
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("x"), 256*1024)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			// One key keeps the fake server's memory flat
+			if _, err := c.PutObject(ctx, "bucket", "bench/object", onlyReader{bytes.NewReader(data)}, ""); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DeleteObjectsByPrefix deletes every object under prefix, following all
+// pages of the listing, using up to concurrency parallel requests and returns
+// how many were deleted.
+// An empty prefix is rejected with ErrEmptyPrefix unless WithAllowEmptyPrefix is set.
+func (c *Client) DeleteObjectsByPrefix(ctx context.Context, bucketName, prefix string, concurrency int, opts ...RequestOption) (int, error) {
+	o := newRequestOptions(opts)
+	if prefix == "" && !o.allowEmptyPrefix {
+		return 0, ErrEmptyPrefix
+	}
+
+	listed, err := c.listAllObjects(ctx, bucketName, prefix, "", opts)
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make([]string, len(listed.Contents))
+	for i, obj := range listed.Contents {
+		keys[i] = obj.Key
+	}
+
+	var deleted atomic.Int64
+	err = c.forEachTransfer(ctx, keys, concurrency, func(key string) error {
+		if err := c.DeleteObject(ctx, bucketName, key, opts...); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		deleted.Add(1)
+		return nil
+	})
+
+	return int(deleted.Load()), err
+}
+
+// PrefixErrors maps each prefix whose listing failed to its error
+type PrefixErrors map[string]error
+
+// Error implements the error interface
+func (e PrefixErrors) Error() string {
+	prefixes := slices.Sorted(maps.Keys(e))
+
+	msgs := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		msgs[i] = fmt.Sprintf("%q: %v", prefix, e[prefix])
+	}
+	return "failed to list prefixes: " + strings.Join(msgs, "; ")
+}
+
+// ListObjectsMulti lists every page of several prefixes with up to
+// concurrency parallel requests and returns the objects keyed by prefix.
+// Prefixes that fail are left out of the result and reported together in a
+// PrefixErrors error, so the successful listings remain usable.
+func (c *Client) ListObjectsMulti(ctx context.Context, bucketName string, prefixes []string, concurrency int, opts ...RequestOption) (map[string][]ObjectInfo, error) {
+	o := newRequestOptions(opts)
+	var mu sync.Mutex
+	results := make(map[string][]ObjectInfo, len(prefixes))
+	failed := PrefixErrors{}
+
+	err := c.forEachTransfer(ctx, slices.Compact(slices.Sorted(slices.Values(prefixes))), concurrency, func(prefix string) error {
+		listed, err := c.listAllObjects(ctx, bucketName, prefix, "", opts)
+		if err == nil && len(listed.Contents) == 0 && o.errorOnEmpty {
+			err = fmt.Errorf("%w under prefix %q", ErrNoObjects, prefix)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failed[prefix] = err
+		} else {
+			results[prefix] = listed.Contents
+		}
+		return nil
+	})
+	if err != nil {
+		return results, err
+	}
+	if len(failed) > 0 {
+		return results, failed
+	}
+
+	return results, nil
+}
+
+// forEachKey runs fn for every key with at most concurrency workers and
+// joins the errors of all failed keys
+func forEachKey(ctx context.Context, keys []string, concurrency int, fn func(key string) error) error {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	jobs := make(chan string)
+	for range min(concurrency, len(keys)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				if err := fn(key); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, key := range keys {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- key
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
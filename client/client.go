@@ -3,14 +3,22 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"crypto/tls"
+	"encoding/base64"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"path"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,6 +27,37 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	apiKey     string
+	bucketName string
+	authMode   AuthHeaderMode
+	maxBody    int64
+	buffers    *bufferPool
+	metadata   *metadataCache
+	clock      Clock
+	events     EventHook
+	ctxHeaders func(ctx context.Context) http.Header
+	onTiming   func(RequestTiming)
+	limiter    requestLimiter
+	cache      ObjectCache
+	resolveURL func(bucket string) string
+	keyPrefix  string
+	transfers  *TransferManager
+
+	// staticHeaders are set with WithStaticHeader on cloned clients
+	staticHeaders     http.Header
+	propagateDeadline bool
+	keyCaseFold       bool
+
+	skewCorrection bool
+	skew           *atomic.Int64
+
+	previewTemplate   string
+	thumbnailTemplate string
+
+	consistencyRetries int
+	consistencyWindow  time.Duration
+
+	logger   *slog.Logger
+	logLevel slog.Level
 }
 
 // ObjectInfo represents object metadata
@@ -29,13 +68,46 @@ type ObjectInfo struct {
 	LastModified time.Time `xml:"LastModified"`
 	ETag         string    `xml:"ETag"`
 	Size         int64     `xml:"Size"`
+	StorageClass string    `xml:"StorageClass"`
+
+	// WeakETag is true when HeadObject received a weak validator (W/"...");
+	// ETag then holds the value without the W/ prefix
+	WeakETag bool `xml:"-"`
+
+	// PartsCount is the number of parts of a multipart object as reported by
+	// HeadObject, and 0 for objects uploaded in one piece or when unknown
+	PartsCount int `xml:"-"`
+
+	// Headers holds the full HeadObject response headers, for server-specific
+	// values such as X-Object-Version that are not modeled above
+	Headers http.Header `xml:"-"`
+}
+
+// CommonPrefix represents a folder-like prefix grouped by the delimiter
+type CommonPrefix struct {
+	Prefix string `xml:"Prefix"`
 }
 
 // ListBucketResult represents the response from list objects
 type ListBucketResult struct {
-	Name     string       `xml:"Name"`
-	Prefix   string       `xml:"Prefix"`
-	Contents []ObjectInfo `xml:"Contents"`
+	Name           string         `xml:"Name"`
+	Prefix         string         `xml:"Prefix"`
+	Delimiter      string         `xml:"Delimiter"`
+	Contents       []ObjectInfo   `xml:"Contents"`
+	CommonPrefixes []CommonPrefix `xml:"CommonPrefixes"`
+
+	// IsTruncated is set when the server returned only part of the listing;
+	// the next page starts after NextContinuationToken or NextMarker
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	NextMarker            string `xml:"NextMarker"`
+}
+
+// BucketInfo represents a created bucket
+type BucketInfo struct {
+	Name         string
+	CreationDate time.Time
+	Location     string
 }
 
 // UploadResult represents the result of an upload operation
@@ -44,16 +116,179 @@ type UploadResult struct {
 	ETag         string
 	PreviewURL   string
 	ThumbnailURL string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+
+	// Media details the server reports for images and videos; zero when
+	// not provided. DurationSeconds is set for video and audio only.
+	Width           int
+	Height          int
+	Format          string
+	DurationSeconds float64
 }
 
+// AuthHeaderMode selects which headers carry the API key
+type AuthHeaderMode int
+
+const (
+	// AuthHeaderBoth sends both Authorization: Bearer and X-API-Key (default)
+	AuthHeaderBoth AuthHeaderMode = iota
+	// AuthHeaderBearer sends only Authorization: Bearer
+	AuthHeaderBearer
+	// AuthHeaderAPIKey sends only X-API-Key
+	AuthHeaderAPIKey
+)
+
 // ClientOptions represents configuration options for the client
 type ClientOptions struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
 	Timeout    time.Duration
+
+	// ResponseHeaderTimeout limits how long to wait for response headers after
+	// the request body is sent. Ignored when HTTPClient is provided.
+	ResponseHeaderTimeout time.Duration
+
+	// DisableCompression stops the transport from advertising gzip and
+	// transparently decompressing responses, so object bytes arrive exactly as
+	// stored (e.g. for checksum verification). Any decompression then has to be
+	// done by the caller. Ignored when HTTPClient is provided.
+	DisableCompression bool
+
+	// ForceHTTP1 disables HTTP/2 negotiation so every request uses HTTP/1.1,
+	// e.g. behind load balancers with HTTP/2 head-of-line issues on large
+	// uploads. HTTP/2 is attempted by default. Ignored when HTTPClient is provided.
+	ForceHTTP1 bool
+
+	// DisableKeepAlives closes each connection after its request instead of
+	// keeping it idle for reuse, so one-shot CLI commands and tests exit
+	// without lingering connections. It costs a new connection (and TLS
+	// handshake) per request, so only set it for single-call use.
+	// Ignored when HTTPClient is provided.
+	DisableKeepAlives bool
+
+	// MinTLSVersion is the lowest TLS version negotiated, e.g. tls.VersionTLS13
+	// (default TLS 1.2). Ignored when HTTPClient is provided; configure the
+	// TLS settings of that client's transport instead.
+	MinTLSVersion uint16
+
+	// CookieJar stores and resends cookies across requests, e.g. the session
+	// cookie of an SSO gateway in front of the server, so the auth flow is
+	// not triggered on every request. Ignored when HTTPClient is provided;
+	// set that client's Jar instead.
+	CookieJar http.CookieJar
+
+	// MaxResponseBodySize caps how much of an error or upload response body is
+	// read into memory (default 1MB). Object bodies are streamed to the caller
+	// and are not subject to this limit.
+	MaxResponseBodySize int64
+
+	// ConsistencyRetries is how many times GetObjectConsistent and
+	// HeadObjectConsistent retry a 404 (default 3)
+	ConsistencyRetries int
+	// ConsistencyWindow bounds the total time spent waiting between those
+	// retries (default 2s)
+	ConsistencyWindow time.Duration
+
+	// CopyBufferSize is the size of the pooled buffers used to copy upload
+	// data (default 32KB)
+	CopyBufferSize int
+
+	// ListCacheTTL enables caching the metadata returned by ListObjects for
+	// this long, so HeadObject on a listed key is answered without a request.
+	// Writes and deletes through this client invalidate affected keys, but
+	// changes made by other clients stay invisible until the entry expires, so
+	// keep it short. Cached results carry no Headers. Disabled by default.
+	ListCacheTTL time.Duration
+
+	// Clock overrides the time source for cache expiry and backoff; meant for
+	// tests (default real time)
+	Clock Clock
+
+	// EventHook receives start and end events for uploads and downloads
+	EventHook EventHook
+
+	// DefaultBucket is used by methods called with an empty bucket name and by
+	// the bucket-less helpers such as Put and Get
+	DefaultBucket string
+
+	// ContextHeaderFunc derives extra headers, such as tenant or trace IDs,
+	// from each request's context. Headers the client already set on the
+	// request (auth, conditionals, ...) take precedence.
+	ContextHeaderFunc func(ctx context.Context) http.Header
+
+	// PreviewURLTemplate and ThumbnailURLTemplate override the templates used
+	// by PreviewURL and ThumbnailURL for servers with a non-standard layout
+	// (defaults DefaultPreviewURLTemplate and DefaultThumbnailURLTemplate)
+	PreviewURLTemplate   string
+	ThumbnailURLTemplate string
+
+	// HTTPTrace enables per-request timing via net/http/httptrace and receives
+	// the DNS, connect, TLS and time-to-first-byte breakdown of every request.
+	// Tracing is off unless this is set.
+	HTTPTrace func(RequestTiming)
+
+	// MaxConcurrentRequests caps the number of requests in flight across all
+	// goroutines sharing the client (0 means unlimited). A request holds its
+	// slot until its response body is closed; waiting respects the context.
+	// This bounds concurrency, not throughput.
+	MaxConcurrentRequests int
+
+	// BaseURLResolver selects the base URL for each request from its bucket,
+	// e.g. to route buckets to regional servers. An empty result falls back
+	// to BaseURL.
+	BaseURLResolver func(bucket string) string
+
+	// ObjectKeyPrefix namespaces every object key, e.g. per tenant. It is
+	// prepended to keys on every operation and to the prefix argument of
+	// listings, and stripped from listed keys and prefixes, so callers only
+	// ever see keys relative to it. A listing prefix of "" lists the whole
+	// namespace.
+	ObjectKeyPrefix string
+
+	// TransferManager shares a concurrency budget and copy buffers across the
+	// bulk operations of this and other clients. When set, CopyBufferSize is
+	// ignored in favor of the manager's buffers.
+	TransferManager *TransferManager
+
+	// KeyCaseFold lowercases object keys and listing prefixes before they are
+	// sent, for data whose keys were normalized to lower case. Object stores
+	// are case-sensitive, so this is off by default: with it, a mixed-case
+	// key can never be reached. Pass WithoutKeyCaseFold to bypass it for a
+	// single call, or use Clone with WithKeyCaseFold(false) for a client.
+	KeyCaseFold bool
+
+	// ObjectCache, e.g. a DiskCache, keeps copies of objects read with
+	// GetObject and serves them while the server confirms via If-None-Match
+	// that they are current. Calls with query or header options bypass it.
+	ObjectCache ObjectCache
+
+	// PropagateDeadline sends the time left until the request context's
+	// deadline as X-Request-Timeout in milliseconds, so the server can abort
+	// work the client will not wait for. Contexts without a deadline send no
+	// header.
+	PropagateDeadline bool
+
+	// ClockSkewCorrection makes ServerTime record the offset between the
+	// server and local clocks, which ServerNow then applies. Useful where
+	// NTP is unreliable and the server rejects skewed timestamps.
+	ClockSkewCorrection bool
+
+	// AuthHeaderMode selects which auth headers are sent (default both)
+	AuthHeaderMode AuthHeaderMode
+
+	// Logger receives one record per request with the method, sanitized URL,
+	// status and latency. Credentials and signatures are never logged.
+	Logger *slog.Logger
+	// LogLevel is the level request records are logged at (default Info)
+	LogLevel slog.Level
 }
 
+// defaultMaxResponseBodySize is the default cap on buffered response bodies
+const defaultMaxResponseBodySize = 1 << 20
+
 // NewClient creates a new GTM Storage client
 func NewClient(options ClientOptions) *Client {
 	if options.HTTPClient == nil {
@@ -62,125 +297,431 @@ func NewClient(options ClientOptions) *Client {
 			timeout = 30 * time.Second
 		}
 		options.HTTPClient = &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: newTransport(options),
+			Jar:       options.CookieJar,
 		}
 	}
 
+	// Copy a provided client so its redirect policy is wrapped, not changed
+	httpClient := *options.HTTPClient
+	httpClient.CheckRedirect = stripAuthOnRedirect(httpClient.CheckRedirect)
+	options.HTTPClient = &httpClient
+
+	buffers := newBufferPool(options.CopyBufferSize)
+	if options.TransferManager != nil {
+		buffers = options.TransferManager.buffers
+	}
+
+	maxBody := options.MaxResponseBodySize
+	if maxBody <= 0 {
+		maxBody = defaultMaxResponseBodySize
+	}
+
+	clock := options.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	consistencyRetries := options.ConsistencyRetries
+	if consistencyRetries <= 0 {
+		consistencyRetries = defaultConsistencyRetries
+	}
+	consistencyWindow := options.ConsistencyWindow
+	if consistencyWindow <= 0 {
+		consistencyWindow = defaultConsistencyWindow
+	}
+
+	previewTemplate := options.PreviewURLTemplate
+	if previewTemplate == "" {
+		previewTemplate = DefaultPreviewURLTemplate
+	}
+	thumbnailTemplate := options.ThumbnailURLTemplate
+	if thumbnailTemplate == "" {
+		thumbnailTemplate = DefaultThumbnailURLTemplate
+	}
+
 	return &Client{
 		baseURL:    strings.TrimRight(options.BaseURL, "/"),
 		httpClient: options.HTTPClient,
 		apiKey:     options.APIKey,
+		bucketName: options.DefaultBucket,
+		authMode:   options.AuthHeaderMode,
+		maxBody:    maxBody,
+		buffers:    buffers,
+		metadata:   newMetadataCache(options.ListCacheTTL, clock),
+		clock:      clock,
+		events:     options.EventHook,
+		ctxHeaders: options.ContextHeaderFunc,
+		onTiming:   options.HTTPTrace,
+		limiter:    newRequestLimiter(options.MaxConcurrentRequests),
+		cache:      options.ObjectCache,
+		resolveURL: options.BaseURLResolver,
+		keyPrefix:  options.ObjectKeyPrefix,
+		transfers:  options.TransferManager,
+
+		propagateDeadline: options.PropagateDeadline,
+		keyCaseFold:       options.KeyCaseFold,
+
+		skewCorrection: options.ClockSkewCorrection,
+		skew:           new(atomic.Int64),
+
+		previewTemplate:   previewTemplate,
+		thumbnailTemplate: thumbnailTemplate,
+
+		consistencyRetries: consistencyRetries,
+		consistencyWindow:  consistencyWindow,
+
+		logger:   options.Logger,
+		logLevel: options.LogLevel,
+	}
+}
+
+// newTransport builds the default transport from the client options
+func newTransport(options ClientOptions) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = options.ResponseHeaderTimeout
+	transport.DisableCompression = options.DisableCompression
+	transport.DisableKeepAlives = options.DisableKeepAlives
+
+	minTLSVersion := options.MinTLSVersion
+	if minTLSVersion == 0 {
+		minTLSVersion = tls.VersionTLS12
+	}
+	transport.TLSClientConfig = &tls.Config{MinVersion: minTLSVersion}
+
+	if options.ForceHTTP1 {
+		// A non-nil empty TLSNextProto map turns off HTTP/2 upgrades
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return transport
+}
+
+// bucket returns bucketName, or the default bucket when it is empty
+func (c *Client) bucket(bucketName string) string {
+	if bucketName == "" {
+		return c.bucketName
+	}
+	return bucketName
+}
+
+// bucketURL returns the API URL of a bucket
+func (c *Client) bucketURL(bucketName string) string {
+	bucketName = c.bucket(bucketName)
+	return fmt.Sprintf("%s/api/%s", c.baseURLFor(bucketName), url.PathEscape(bucketName))
+}
+
+// baseURLFor returns the base URL serving a bucket
+func (c *Client) baseURLFor(bucketName string) string {
+	if c.resolveURL != nil {
+		if base := c.resolveURL(bucketName); base != "" {
+			return strings.TrimRight(base, "/")
+		}
 	}
+	return c.baseURL
+}
+
+// objectURL returns the API URL of an object with its key escaped; o may be nil
+func (c *Client) objectURL(bucketName, objectKey string, o *requestOptions) string {
+	return fmt.Sprintf("%s/%s", c.bucketURL(bucketName), escapeKey(c.serverKey(objectKey, o)))
+}
+
+// readErrorBody reads at most maxBody bytes of a failed response for the error message
+func (c *Client) readErrorBody(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, c.maxBody))
+	return string(body)
 }
 
 // addAuth adds authentication to the request
 func (c *Client) addAuth(req *http.Request) {
-	if c.apiKey != "" {
+	if c.apiKey == "" {
+		return
+	}
+
+	if c.authMode != AuthHeaderAPIKey {
 		req.Header.Set("Authorization", "Bearer "+c.apiKey)
-		// 或者根据实际的认证方式设置
+	}
+	// 或者根据实际的认证方式设置
+	if c.authMode != AuthHeaderBearer {
 		req.Header.Set("X-API-Key", c.apiKey)
 	}
 }
 
+// stripAuthOnRedirect wraps a redirect policy so that auth headers are not
+// forwarded to another host. net/http already drops Authorization there, but
+// not the custom X-API-Key header.
+func stripAuthOnRedirect(next func(req *http.Request, via []*http.Request) error) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > 0 && req.URL.Host != via[0].URL.Host {
+			req.Header.Del("Authorization")
+			req.Header.Del("X-API-Key")
+		}
+		if next != nil {
+			return next(req, via)
+		}
+		// Same limit as the default policy
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+}
+
+// do sends the request and records it with the configured logger.
+// It is the single place every request passes through.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.ctxHeaders != nil {
+		for key, values := range c.ctxHeaders(req.Context()) {
+			key = http.CanonicalHeaderKey(key)
+			if _, exists := req.Header[key]; !exists {
+				req.Header[key] = values
+			}
+		}
+	}
+
+	for key, values := range c.staticHeaders {
+		if _, exists := req.Header[key]; !exists {
+			req.Header[key] = values
+		}
+	}
+
+	if c.propagateDeadline {
+		if deadline, ok := req.Context().Deadline(); ok {
+			if remaining := deadline.Sub(c.clock.Now()); remaining > 0 {
+				req.Header.Set("X-Request-Timeout", strconv.FormatInt(remaining.Milliseconds(), 10))
+			}
+		}
+	}
+
+	// Ask for XML so error documents have a predictable format; object
+	// bodies are returned as stored regardless
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/xml, application/json;q=0.9, */*;q=0.8")
+	}
+
+	if err := c.limiter.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+
+	var tracer *requestTracer
+	if c.onTiming != nil {
+		req, tracer = c.traceRequest(req)
+	}
+
+	start := c.clock.Now()
+	resp, err := c.httpClient.Do(req)
+	c.logRequest(req, resp, err, c.clock.Now().Sub(start))
+
+	if tracer != nil {
+		c.onTiming(tracer.finish())
+	}
+
+	if err != nil {
+		c.limiter.release()
+		return nil, err
+	}
+	if c.limiter != nil {
+		resp.Body = &limitedBody{ReadCloser: resp.Body, limiter: c.limiter}
+	}
+	return resp, nil
+}
+
 // MakeBucket creates a new bucket
-func (c *Client) MakeBucket(ctx context.Context, bucketName string) error {
-	url := fmt.Sprintf("%s/api/%s", c.baseURL, bucketName)
+func (c *Client) MakeBucket(ctx context.Context, bucketName string, opts ...RequestOption) error {
+	_, err := c.MakeBucketWithResult(ctx, bucketName, opts...)
+	return err
+}
+
+// MakeBucketWithResult creates a new bucket and reports where and when it was created
+func (c *Client) MakeBucketWithResult(ctx context.Context, bucketName string, opts ...RequestOption) (*BucketInfo, error) {
+	url := c.bucketURL(bucketName)
+	o := newRequestOptions(opts)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	o.apply(req)
 	c.addAuth(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create bucket: %s (status: %d)", string(body), resp.StatusCode)
+		return nil, c.statusError(resp, "create bucket")
 	}
 
-	return nil
+	// The response Date is the closest thing to a creation timestamp
+	creationDate, _ := time.Parse(http.TimeFormat, resp.Header.Get("Date"))
+
+	return &BucketInfo{
+		Name:         bucketName,
+		CreationDate: creationDate,
+		Location:     resp.Header.Get("Location"),
+	}, nil
 }
 
 // DeleteBucket deletes a bucket
-func (c *Client) DeleteBucket(ctx context.Context, bucketName string) error {
-	url := fmt.Sprintf("%s/api/%s", c.baseURL, bucketName)
+func (c *Client) DeleteBucket(ctx context.Context, bucketName string, opts ...RequestOption) error {
+	url := c.bucketURL(bucketName)
+	o := newRequestOptions(opts)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
+	o.apply(req)
 	c.addAuth(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete bucket: %s (status: %d)", string(body), resp.StatusCode)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return c.statusError(resp, "delete bucket")
 	}
 
 	return nil
 }
 
-// PutObject uploads an object to the bucket
-func (c *Client) PutObject(ctx context.Context, bucketName, objectKey string, reader io.Reader, filename string) (*UploadResult, error) {
-	url := fmt.Sprintf("%s/api/%s/%s", c.baseURL, bucketName, objectKey)
+// PutObject uploads an object to the bucket. The body is buffered, so an
+// upload interrupted after part of it was sent is retried once.
+func (c *Client) PutObject(ctx context.Context, bucketName, objectKey string, reader io.Reader, filename string, opts ...RequestOption) (result *UploadResult, err error) {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
+
+	c.transferStart(OpPutObject, bucketName, objectKey, readerSize(reader))
+	defer func() { c.uploadEnd(OpPutObject, bucketName, objectKey, result, err) }()
+
+	// A nil reader uploads an empty object, e.g. a folder marker
+	if reader == nil {
+		reader = http.NoBody
+	}
+	// Servers treat a part without a filename as a plain form value, so an
+	// empty (zero-byte) file would be rejected as missing
+	if filename == "" {
+		filename = path.Base(objectKey)
+	}
 
 	// Create multipart form
 	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
+	writer, err := o.newMultipartWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
 
-	// Add file field
-	fileWriter, err := writer.CreateFormFile("file", filename)
+	// Add form fields and the file field
+	fileWriter, err := o.createFormFile(writer, filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+		return nil, err
 	}
 
-	if _, err := io.Copy(fileWriter, reader); err != nil {
+	written, err := c.buffers.copyBuffer(fileWriter, reader)
+	if err != nil {
 		return nil, fmt.Errorf("failed to copy file data: %w", err)
 	}
 
 	writer.Close()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, &buf)
+	result, err = c.sendUpload(ctx, url, bucketName, objectKey, buf.Bytes(), writer.FormDataContentType(), written, o)
+	if errors.Is(err, ErrUploadInterrupted) && ctx.Err() == nil {
+		// The body is buffered and the idempotency key is reused, so one
+		// resend after a dropped connection is safe
+		result, err = c.sendUpload(ctx, url, bucketName, objectKey, buf.Bytes(), writer.FormDataContentType(), written, o)
+	}
+	if err != nil && o.autoCreateBucket && isNoSuchBucket(err) {
+		// Create the bucket once and retry once; a second failure is returned as is
+		if createErr := c.createBucketIfMissing(ctx, bucketName); createErr != nil {
+			return nil, errors.Join(err, createErr)
+		}
+		result, err = c.sendUpload(ctx, url, bucketName, objectKey, buf.Bytes(), writer.FormDataContentType(), written, o)
+	}
+	return result, err
+}
+
+// sendUpload sends an encoded multipart upload body and parses the response
+func (c *Client) sendUpload(ctx context.Context, url, bucketName, objectKey string, body []byte, contentType string, written int64, o *requestOptions) (*UploadResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	// Count what reaches the transport to report partial sends
+	counter := &countingReader{r: req.Body}
+	req.Body = io.NopCloser(counter)
+
+	req.Header.Set("Content-Type", contentType)
+	if o.contentMD5Precheck {
+		sum := md5.Sum(body)
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+		req.Header.Set("Expect", "100-continue")
+	}
+	o.setIdempotencyKey(req)
+	o.setCreateOnly(req)
+	o.apply(req)
 	c.addAuth(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
+	c.metadata.invalidate(c.bucket(bucketName), objectKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, uploadError(err, counter.n.Load())
 	}
 	defer resp.Body.Close()
 
+	return c.parseUploadResponse(resp, objectKey, written, o)
+}
+
+// ReplaceObject uploads new contents for an object only if its current ETag
+// matches expectedETag, so concurrent writers cannot clobber each other.
+// A mismatch returns an error matching ErrPreconditionFailed.
+func (c *Client) ReplaceObject(ctx context.Context, bucketName, objectKey string, reader io.Reader, filename, expectedETag string, opts ...RequestOption) (*UploadResult, error) {
+	opts = append(slices.Clip(opts), withHeader("If-Match", quoteETag(expectedETag)))
+	return c.PutObject(ctx, bucketName, objectKey, reader, filename, opts...)
+}
+
+// parseUploadResponse checks the upload status and builds the result from
+// the response headers and body. written is the number of bytes sent.
+func (c *Client) parseUploadResponse(resp *http.Response, objectKey string, written int64, o *requestOptions) (*UploadResult, error) {
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to upload object: %s (status: %d)", string(body), resp.StatusCode)
+		err := c.statusError(resp, "upload object")
+		if o.createOnly && resp.StatusCode == http.StatusPreconditionFailed {
+			return nil, fmt.Errorf("%w: %w", ErrObjectExists, err)
+		}
+		return nil, err
 	}
 
 	// Parse response
-	body, err := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	result := &UploadResult{
-		Key:  objectKey,
-		ETag: resp.Header.Get("ETag"),
+		Key:         objectKey,
+		ETag:        resp.Header.Get("ETag"),
+		Size:        written,
+		ContentType: resp.Header.Get("X-Object-Content-Type"),
+	}
+
+	// Prefer the server-reported metadata when present
+	if size, err := strconv.ParseInt(resp.Header.Get("X-Object-Size"), 10, 64); err == nil {
+		result.Size = size
 	}
+	if lastModified, err := time.Parse(http.TimeFormat, resp.Header.Get("Last-Modified")); err == nil {
+		result.LastModified = lastModified
+	}
+	result.Width, _ = strconv.Atoi(resp.Header.Get("X-Object-Width"))
+	result.Height, _ = strconv.Atoi(resp.Header.Get("X-Object-Height"))
+	result.Format = resp.Header.Get("X-Object-Format")
+	result.DurationSeconds, _ = strconv.ParseFloat(resp.Header.Get("X-Object-Duration"), 64)
 
 	// Extract URLs from response body (简单解析，实际可能需要更复杂的解析)
 	bodyStr := string(body)
@@ -205,32 +746,206 @@ func (c *Client) PutObject(ctx context.Context, bucketName, objectKey string, re
 	return result, nil
 }
 
-// GetObject retrieves an object from the bucket
-func (c *Client) GetObject(ctx context.Context, bucketName, objectKey string) (io.ReadCloser, error) {
-	url := fmt.Sprintf("%s/api/%s/%s", c.baseURL, bucketName, objectKey)
+// GetObject retrieves an object from the bucket.
+// With WithFallbackKey the returned reader is a *FallbackReader; with
+// WithAcceptEncoding and WithRawEncoding it is an *EncodedReader.
+func (c *Client) GetObject(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (io.ReadCloser, error) {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
+
+	if o.fallbackKey != "" {
+		return c.getObjectWithFallback(ctx, bucketName, objectKey, o.fallbackKey, opts)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	o.apply(req)
+
+	// An explicit Accept-Encoding turns off the transport's transparent gzip
+	if o.acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", o.acceptEncoding)
+	}
+
+	// Conditional, subresource or encoded reads must reach the server unchanged
+	useCache := c.cache != nil && len(o.query) == 0 && len(o.headers) == 0 && o.acceptEncoding == ""
+	var cached io.ReadCloser
+	if useCache {
+		if body, etag, ok := c.cache.Get(c.bucket(bucketName), objectKey); ok {
+			cached = body
+			req.Header.Set("If-None-Match", quoteETag(etag))
+		}
+	}
+
+	c.transferStart(OpGetObject, bucketName, objectKey, -1)
+
+	resp, err := c.do(req)
+	if err != nil {
+		if cached != nil {
+			cached.Close()
+		}
+		err = fmt.Errorf("failed to make request: %w", err)
+		c.transferEnd(OpGetObject, bucketName, objectKey, 0, err)
+		return nil, err
+	}
+
+	if cached != nil {
+		if resp.StatusCode == http.StatusNotModified {
+			resp.Body.Close()
+			return c.trackDownload(OpGetObject, bucketName, objectKey, cached), nil
+		}
+		cached.Close()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		err := c.statusError(resp, "get object")
+		c.transferEnd(OpGetObject, bucketName, objectKey, 0, err)
+		return nil, err
+	}
+
+	body := resp.Body
+	switch {
+	case o.acceptEncoding != "" && o.rawEncoding:
+		body = &EncodedReader{ReadCloser: body, ContentEncoding: resp.Header.Get("Content-Encoding")}
+	case o.acceptEncoding != "":
+		if body, err = decodeBody(body, resp.Header.Get("Content-Encoding")); err != nil {
+			c.transferEnd(OpGetObject, bucketName, objectKey, 0, err)
+			return nil, err
+		}
+	}
+	if etag := resp.Header.Get("ETag"); useCache && etag != "" {
+		// Keep the header as sent: trimming quotes would mangle weak W/"..." values
+		if w, err := c.cache.Create(c.bucket(bucketName), objectKey, etag); err == nil {
+			body = &cachingBody{ReadCloser: body, w: w}
+		}
+	}
+
+	return c.trackDownload(OpGetObject, bucketName, objectKey, body), nil
+}
+
+// FallbackReader is the body returned by GetObject with WithFallbackKey
+type FallbackReader struct {
+	io.ReadCloser
+	// Key is the key of the object actually returned
+	Key string
+	// Fallback is true when the requested object was missing and the
+	// fallback object was returned instead
+	Fallback bool
+}
+
+// getObjectWithFallback retrieves objectKey, or fallbackKey if it does not exist
+func (c *Client) getObjectWithFallback(ctx context.Context, bucketName, objectKey, fallbackKey string, opts []RequestOption) (io.ReadCloser, error) {
+	opts = append(slices.Clip(opts), WithFallbackKey(""))
+
+	body, err := c.GetObject(ctx, bucketName, objectKey, opts...)
+	if err == nil {
+		return &FallbackReader{ReadCloser: body, Key: objectKey}, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	body, err = c.GetObject(ctx, bucketName, fallbackKey, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fallback %s: %w", fallbackKey, err)
+	}
+	return &FallbackReader{ReadCloser: body, Key: fallbackKey, Fallback: true}, nil
+}
+
+// defaultMaxObjectBytes caps GetObjectBytes unless set with WithMaxBytes
+const defaultMaxObjectBytes = 32 << 20
+
+// GetObjectBytes reads a whole object into memory, e.g. a small config file.
+// Objects larger than the limit set with WithMaxBytes (default 32MB) fail
+// with ErrObjectTooLarge instead of being buffered.
+func (c *Client) GetObjectBytes(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) ([]byte, error) {
+	o := newRequestOptions(opts)
+	limit := o.maxBytes
+	if limit <= 0 {
+		limit = defaultMaxObjectBytes
+	}
+
+	body, err := c.GetObject(ctx, bucketName, objectKey, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrObjectTooLarge, objectKey, limit)
+	}
+
+	return data, nil
+}
+
+// GetObjectIfMatch retrieves an object only if its current ETag matches etag.
+// A mismatch returns an error matching ErrPreconditionFailed.
+func (c *Client) GetObjectIfMatch(ctx context.Context, bucketName, objectKey, etag string, opts ...RequestOption) (io.ReadCloser, error) {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("If-Match", quoteETag(etag))
+
+	o.apply(req)
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		defer resp.Body.Close()
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get object: %s (status: %d)", string(body), resp.StatusCode)
+		return nil, c.statusError(resp, "get object")
 	}
 
 	return resp.Body, nil
 }
 
-// GetObjectRange retrieves a range of bytes from an object
-func (c *Client) GetObjectRange(ctx context.Context, bucketName, objectKey string, start, end int64) (io.ReadCloser, error) {
-	url := fmt.Sprintf("%s/api/%s/%s", c.baseURL, bucketName, objectKey)
+// RangeReader is the body returned by GetObjectRange
+type RangeReader struct {
+	io.ReadCloser
+	// Partial is true when the server returned only the requested range (206)
+	// and false when it returned the full object (200), e.g. because the
+	// object no longer matches the WithIfRange ETag
+	Partial bool
+
+	// Start and End are the inclusive byte offsets the server actually
+	// returned, which may be clamped from the requested range. Size is the
+	// total object size. Unknown values are -1.
+	Start int64
+	End   int64
+	Size  int64
+}
+
+// GetObjectRange retrieves a range of bytes from an object.
+// An end of 0 with a positive start reads to the end of the object.
+// The returned reader is a *RangeReader reporting whether the range was honored.
+func (c *Client) GetObjectRange(ctx context.Context, bucketName, objectKey string, start, end int64, opts ...RequestOption) (io.ReadCloser, error) {
+	r, err := c.GetObjectRangeWithInfo(ctx, bucketName, objectKey, start, end, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetObjectRangeWithInfo is GetObjectRange returning the range the server
+// actually sent, parsed from Content-Range, e.g. to place clamped ranges
+// correctly when assembling a parallel download
+func (c *Client) GetObjectRangeWithInfo(ctx context.Context, bucketName, objectKey string, start, end int64, opts ...RequestOption) (*RangeReader, error) {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -238,128 +953,588 @@ func (c *Client) GetObjectRange(ctx context.Context, bucketName, objectKey strin
 	}
 
 	if start > 0 || end > 0 {
-		rangeHeader := fmt.Sprintf("bytes=%d-%d", start, end)
+		rangeHeader := fmt.Sprintf("bytes=%d-", start)
+		if end > 0 {
+			rangeHeader += strconv.FormatInt(end, 10)
+		}
 		req.Header.Set("Range", rangeHeader)
+
+		if o.ifRange != "" {
+			req.Header.Set("If-Range", quoteETag(o.ifRange))
+		}
 	}
 
-	resp, err := c.httpClient.Do(req)
+	o.apply(req)
+
+	c.transferStart(OpGetObjectRange, bucketName, objectKey, -1)
+
+	resp, err := c.do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		err = fmt.Errorf("failed to make request: %w", err)
+		c.transferEnd(OpGetObjectRange, bucketName, objectKey, 0, err)
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
 		defer resp.Body.Close()
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get object: %s (status: %d)", string(body), resp.StatusCode)
+		err := c.statusError(resp, "get object")
+		c.transferEnd(OpGetObjectRange, bucketName, objectKey, 0, err)
+		return nil, err
 	}
 
-	return resp.Body, nil
+	r := &RangeReader{
+		ReadCloser: c.trackDownload(OpGetObjectRange, bucketName, objectKey, resp.Body),
+		Partial:    resp.StatusCode == http.StatusPartialContent,
+		Start:      -1,
+		End:        -1,
+		Size:       -1,
+	}
+	if r.Partial {
+		r.Start, r.End, r.Size = parseContentRange(resp.Header.Get("Content-Range"))
+	} else if resp.ContentLength >= 0 {
+		r.Start, r.End, r.Size = 0, resp.ContentLength-1, resp.ContentLength
+	}
+
+	return r, nil
+}
+
+// parseContentRange parses a "bytes start-end/size" header, returning -1 for
+// parts that are missing or unknown ("*")
+func parseContentRange(header string) (start, end, size int64) {
+	start, end, size = -1, -1, -1
+
+	spec, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return
+	}
+	rng, total, _ := strings.Cut(spec, "/")
+	if n, err := strconv.ParseInt(total, 10, 64); err == nil {
+		size = n
+	}
+	first, last, ok := strings.Cut(rng, "-")
+	if !ok {
+		return
+	}
+	if n, err := strconv.ParseInt(first, 10, 64); err == nil {
+		start = n
+	}
+	if n, err := strconv.ParseInt(last, 10, 64); err == nil {
+		end = n
+	}
+	return
+}
+
+// PeekObject returns at most the first n bytes of an object, e.g. for sniffing
+// its format, without downloading the rest
+func (c *Client) PeekObject(ctx context.Context, bucketName, objectKey string, n int64, opts ...RequestOption) ([]byte, error) {
+	if n <= 0 {
+		return []byte{}, nil
+	}
+
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", n-1))
+
+	o.apply(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	// Closing before the body is drained drops the connection instead of
+	// reading the rest of an object the server sent in full
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return nil, c.statusError(resp, "peek object")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, n))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+
+	return data, nil
+}
+
+// parseETag splits an ETag header into its unquoted value and whether it is weak
+func parseETag(header string) (etag string, weak bool) {
+	header, weak = strings.CutPrefix(header, "W/")
+	return strings.Trim(header, "\""), weak
+}
+
+// Validator returns the ETag formatted for conditional request headers,
+// keeping the W/ prefix of weak ETags
+func (info *ObjectInfo) Validator() string {
+	if info.WeakETag {
+		return "W/" + quoteETag(info.ETag)
+	}
+	return quoteETag(info.ETag)
+}
+
+// quoteETag formats an ETag as a quoted validator unless it already is one
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, "\"") || strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return "\"" + etag + "\""
 }
 
 // DeleteObject deletes an object from the bucket
-func (c *Client) DeleteObject(ctx context.Context, bucketName, objectKey string) error {
-	url := fmt.Sprintf("%s/api/%s/%s", c.baseURL, bucketName, objectKey)
+func (c *Client) DeleteObject(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) error {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
+	o.apply(req)
 	c.addAuth(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
+	c.metadata.invalidate(c.bucket(bucketName), objectKey)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete object: %s (status: %d)", string(body), resp.StatusCode)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return c.statusError(resp, "delete object")
 	}
 
 	return nil
 }
 
 // HeadObject retrieves object metadata
-func (c *Client) HeadObject(ctx context.Context, bucketName, objectKey string) (*ObjectInfo, error) {
-	url := fmt.Sprintf("%s/api/%s/%s", c.baseURL, bucketName, objectKey)
+func (c *Client) HeadObject(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (*ObjectInfo, error) {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
+
+	// Listings carry no subresource data, so only plain HEADs use the cache
+	if len(o.query) == 0 && len(o.headers) == 0 {
+		if info, ok := c.metadata.get(c.bucket(bucketName), objectKey); ok {
+			return info, nil
+		}
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	o.apply(req)
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get object metadata: %s (status: %d)", string(body), resp.StatusCode)
+		return nil, c.statusError(resp, "get object metadata")
 	}
 
 	// Parse Last-Modified
 	lastModified, _ := time.Parse(http.TimeFormat, resp.Header.Get("Last-Modified"))
+	partsCount, _ := strconv.Atoi(resp.Header.Get("X-Object-Parts-Count"))
+	etag, weak := parseETag(resp.Header.Get("ETag"))
 
 	return &ObjectInfo{
 		Key:          objectKey,
 		ContentType:  resp.Header.Get("Content-Type"),
 		LastModified: lastModified,
-		ETag:         strings.Trim(resp.Header.Get("ETag"), "\""),
+		ETag:         etag,
+		WeakETag:     weak,
 		Size:         resp.ContentLength,
+		StorageClass: resp.Header.Get("X-Storage-Class"),
+		PartsCount:   partsCount,
+		Headers:      resp.Header.Clone(),
 	}, nil
 }
 
+// ObjectExists reports whether an object exists in the bucket
+func (c *Client) ObjectExists(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (bool, error) {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	o.apply(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, c.statusError(resp, "check object")
+	}
+}
+
+// maxWaitInterval caps the backoff between WaitForObject polls
+const maxWaitInterval = 30 * time.Second
+
+// WaitForObject polls until the object exists, doubling the interval between polls.
+// It returns ctx.Err() if the context expires first.
+func (c *Client) WaitForObject(ctx context.Context, bucketName, objectKey string, interval time.Duration, opts ...RequestOption) error {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	for {
+		exists, err := c.ObjectExists(ctx, bucketName, objectKey, opts...)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if exists {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.clock.After(interval):
+		}
+
+		interval = min(interval*2, maxWaitInterval)
+	}
+}
+
 // ListObjects lists objects in a bucket
-func (c *Client) ListObjects(ctx context.Context, bucketName string, prefix string) ([]ObjectInfo, error) {
-	baseURL := fmt.Sprintf("%s/api/%s", c.baseURL, bucketName)
+func (c *Client) ListObjects(ctx context.Context, bucketName string, prefix string, opts ...RequestOption) ([]ObjectInfo, error) {
+	result, err := c.listObjects(ctx, bucketName, prefix, "", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Contents) == 0 && newRequestOptions(opts).errorOnEmpty {
+		return nil, fmt.Errorf("%w under prefix %q", ErrNoObjects, prefix)
+	}
+
+	return result.Contents, nil
+}
 
-	// Add prefix parameter if provided
+// ListPrefixes lists the immediate subfolders of path, i.e. the common
+// prefixes one level below it, ignoring objects, across every page of the
+// listing. An empty path lists the top-level folders of the bucket.
+func (c *Client) ListPrefixes(ctx context.Context, bucketName, path string, opts ...RequestOption) ([]string, error) {
+	prefix := strings.TrimSuffix(path, "/")
 	if prefix != "" {
-		params := url.Values{}
-		params.Set("prefix", prefix)
-		baseURL += "?" + params.Encode()
+		prefix += "/"
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
+	result, err := c.listAllObjects(ctx, bucketName, prefix, "/", opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	prefixes := make([]string, len(result.CommonPrefixes))
+	for i, p := range result.CommonPrefixes {
+		prefixes[i] = p.Prefix
+	}
+	return prefixes, nil
+}
+
+// IsPrefix reports whether path is a folder-like prefix with children rather than a leaf object
+func (c *Client) IsPrefix(ctx context.Context, bucketName, path string, opts ...RequestOption) (bool, error) {
+	prefix := strings.TrimSuffix(path, "/") + "/"
+
+	result, err := c.listObjects(ctx, bucketName, prefix, "/", opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return false, err
+	}
+
+	return len(result.Contents) > 0 || len(result.CommonPrefixes) > 0, nil
+}
+
+// ListObjectKeys lists only the keys of objects in a bucket.
+// The response is decoded token by token and everything but <Key> is
+// skipped, which is much cheaper than ListObjects for large listings.
+func (c *Client) ListObjectKeys(ctx context.Context, bucketName, prefix string, opts ...RequestOption) ([]string, error) {
+	resp, err := c.openList(ctx, bucketName, prefix, "", listCursor{}, opts)
+	if err != nil {
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to list objects: %s (status: %d)", string(body), resp.StatusCode)
+	var keys []string
+	inContents := false
+	decoder := xml.NewDecoder(resp.Body)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch {
+			case t.Name.Local == "Contents":
+				inContents = true
+			case inContents && t.Name.Local == "Key":
+				var key string
+				if err := decoder.DecodeElement(&key, &t); err != nil {
+					return nil, fmt.Errorf("failed to parse response: %w", err)
+				}
+				keys = append(keys, c.relativeKey(key))
+			case inContents:
+				if err := decoder.Skip(); err != nil {
+					return nil, fmt.Errorf("failed to parse response: %w", err)
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "Contents" {
+				inContents = false
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// listCursor is the position of a listing page: a continuation token when
+// the server issues them, a marker key otherwise
+type listCursor struct {
+	token  string
+	marker string
+}
+
+// listObjects fetches the raw list result, grouping keys by delimiter when one is given
+func (c *Client) listObjects(ctx context.Context, bucketName, prefix, delimiter string, opts []RequestOption) (*ListBucketResult, error) {
+	return c.listObjectsPage(ctx, bucketName, prefix, delimiter, listCursor{}, opts)
+}
+
+// listAllObjects is listObjects following IsTruncated through every page
+func (c *Client) listAllObjects(ctx context.Context, bucketName, prefix, delimiter string, opts []RequestOption) (*ListBucketResult, error) {
+	var (
+		all    *ListBucketResult
+		cursor listCursor
+	)
+	for {
+		page, err := c.listObjectsPage(ctx, bucketName, prefix, delimiter, cursor, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if all == nil {
+			all = page
+		} else {
+			all.Contents = append(all.Contents, page.Contents...)
+			all.CommonPrefixes = append(all.CommonPrefixes, page.CommonPrefixes...)
+		}
+		if !page.IsTruncated {
+			break
+		}
+
+		next := listCursor{token: page.NextContinuationToken, marker: page.NextMarker}
+		if next == (listCursor{}) || next == cursor {
+			return nil, fmt.Errorf("failed to list objects: truncated listing without a next page")
+		}
+		cursor = next
+	}
+
+	all.IsTruncated = false
+	all.NextContinuationToken = ""
+	all.NextMarker = ""
+	return all, nil
+}
+
+// listObjectsPage fetches one page of a listing starting at cursor
+func (c *Client) listObjectsPage(ctx context.Context, bucketName, prefix, delimiter string, cursor listCursor, opts []RequestOption) (*ListBucketResult, error) {
+	resp, err := c.openList(ctx, bucketName, prefix, delimiter, cursor, opts)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var result ListBucketResult
 	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return result.Contents, nil
+	// Servers without NextMarker expect the last returned key as the marker
+	if result.IsTruncated && result.NextContinuationToken == "" && result.NextMarker == "" {
+		if n := len(result.Contents); n > 0 {
+			result.NextMarker = result.Contents[n-1].Key
+		}
+		if n := len(result.CommonPrefixes); n > 0 && result.CommonPrefixes[n-1].Prefix > result.NextMarker {
+			result.NextMarker = result.CommonPrefixes[n-1].Prefix
+		}
+	}
+
+	if c.keyPrefix != "" {
+		result.Prefix = c.relativeKey(result.Prefix)
+		for i := range result.Contents {
+			result.Contents[i].Key = c.relativeKey(result.Contents[i].Key)
+		}
+		for i := range result.CommonPrefixes {
+			result.CommonPrefixes[i].Prefix = c.relativeKey(result.CommonPrefixes[i].Prefix)
+		}
+	}
+
+	c.metadata.store(c.bucket(bucketName), result.Contents)
+
+	return &result, nil
+}
+
+// serverKey maps a caller's key or prefix to the key stored on the server,
+// applying ObjectKeyPrefix and, unless o disables it, KeyCaseFold; o may be nil
+func (c *Client) serverKey(key string, o *requestOptions) string {
+	if c.keyCaseFold && (o == nil || !o.noKeyCaseFold) {
+		key = strings.ToLower(key)
+	}
+	return c.keyPrefix + key
+}
+
+// relativeKey strips the ObjectKeyPrefix from a key returned by the server
+func (c *Client) relativeKey(key string) string {
+	return strings.TrimPrefix(key, c.keyPrefix)
+}
+
+// openList sends a list request and returns the successful response for decoding
+func (c *Client) openList(ctx context.Context, bucketName, prefix, delimiter string, cursor listCursor, opts []RequestOption) (*http.Response, error) {
+	baseURL := c.bucketURL(bucketName)
+	o := newRequestOptions(opts)
+
+	// Add prefix and delimiter parameters if provided
+	prefix = c.serverKey(prefix, o)
+	params := url.Values{}
+	if prefix != "" {
+		params.Set("prefix", prefix)
+	}
+	if delimiter != "" {
+		params.Set("delimiter", delimiter)
+	}
+	if cursor.token != "" {
+		params.Set("continuation-token", cursor.token)
+	} else if cursor.marker != "" {
+		params.Set("marker", cursor.marker)
+	}
+	if len(params) > 0 {
+		baseURL += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	o.apply(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, c.statusError(resp, "list objects")
+	}
+
+	return resp, nil
 }
 
 // PutObjectFromFile uploads a file to the bucket
-func (c *Client) PutObjectFromFile(ctx context.Context, bucketName, objectKey, filePath string) (*UploadResult, error) {
+func (c *Client) PutObjectFromFile(ctx context.Context, bucketName, objectKey, filePath string, opts ...RequestOption) (*UploadResult, error) {
 	file, err := http.DefaultClient.Head(filePath) // 这里简化了，实际应该打开本地文件
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 
 	filename := filepath.Base(filePath)
-	return c.PutObject(ctx, bucketName, objectKey, file.Body, filename)
+	return c.PutObject(ctx, bucketName, objectKey, file.Body, filename, opts...)
 }
 
-// GetObjectURL returns the direct URL to access an object
+// GetObjectURL returns the direct URL to access an object.
+// Use GetObjectURLSafe to detect a misconfigured base URL.
 func (c *Client) GetObjectURL(bucketName, objectKey string) string {
-	return fmt.Sprintf("%s/api/%s/%s", c.baseURL, bucketName, objectKey)
+	return c.objectURL(bucketName, objectKey, nil)
+}
+
+// GetObjectURLSafe returns the direct URL to access an object, or an error if
+// the base URL is not an absolute http or https URL
+func (c *Client) GetObjectURLSafe(bucketName, objectKey string) (string, error) {
+	baseURL := c.baseURLFor(c.bucket(bucketName))
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base url: %w", err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return "", fmt.Errorf("invalid base url %q: scheme must be http or https", baseURL)
+	}
+	if base.Host == "" {
+		return "", fmt.Errorf("invalid base url %q: missing host", baseURL)
+	}
+	if c.bucket(bucketName) == "" || objectKey == "" {
+		return "", fmt.Errorf("bucket name and object key are required")
+	}
+
+	return c.GetObjectURL(bucketName, objectKey), nil
+}
+
+// FetchURL downloads an absolute URL returned by the API, such as
+// UploadResult.PreviewURL, ThumbnailURL or a presigned URL, using the
+// client's transport. The URL must point at the configured base URL's host.
+// No auth headers are sent: such URLs carry their own authorization and may
+// redirect to a CDN that must not see the API key.
+func (c *Client) FetchURL(ctx context.Context, rawURL string, opts ...RequestOption) (io.ReadCloser, error) {
+	o := newRequestOptions(opts)
+
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+
+	// Relative URLs are resolved against the base URL
+	target = base.ResolveReference(target)
+	if target.Scheme != base.Scheme || target.Host != base.Host {
+		return nil, fmt.Errorf("refusing to fetch %s: host does not match base url %s", target.Redacted(), base.Host)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	o.apply(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, c.statusError(resp, "fetch url")
+	}
+
+	return resp.Body, nil
 }
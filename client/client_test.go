@@ -0,0 +1,437 @@
+package client
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeObject is an object held by fakeServer
+type fakeObject struct {
+	data         []byte
+	storageClass string
+}
+
+// fakeServer is an in-memory object store speaking the subset of the
+// protocol the tests need. Paths are /api/<bucket>/<key>.
+type fakeServer struct {
+	mu      sync.Mutex
+	objects map[string]fakeObject
+
+	// pageSize truncates listings after that many entries when positive
+	pageSize int
+	// weakETags makes objects carry weak ETags (W/"...")
+	weakETags bool
+	// fullReads counts GETs answered with the object body
+	fullReads int
+	// corruptCopies makes copies differ from their source in the first byte
+	corruptCopies bool
+}
+
+// newTestClient starts a fakeServer and returns a client pointed at it
+func newTestClient(t testing.TB) (*Client, *fakeServer) {
+	t.Helper()
+	return newTestClientWithOptions(t, ClientOptions{})
+}
+
+// newTestClientWithOptions is newTestClient with extra client options; the
+// base URL and API key are filled in
+func newTestClientWithOptions(t testing.TB, options ClientOptions) (*Client, *fakeServer) {
+	t.Helper()
+
+	fake := &fakeServer{objects: make(map[string]fakeObject)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	options.BaseURL = srv.URL
+	options.APIKey = "test-key"
+	return NewClient(options), fake
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/"), "/")
+	id := bucket + "/" + key
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if key == "" {
+		switch r.Method {
+		case http.MethodGet:
+			f.list(w, r, bucket)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "unsupported", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	obj, exists := f.objects[id]
+	switch r.Method {
+	case http.MethodPut:
+		if r.Header.Get("If-None-Match") == "*" && exists {
+			http.Error(w, "object exists", http.StatusPreconditionFailed)
+			return
+		}
+		if source := r.Header.Get("X-Copy-Source"); source != "" {
+			f.copy(w, r, id, strings.TrimPrefix(source, "/"))
+			return
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, err := io.ReadAll(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.objects[id] = fakeObject{data: data, storageClass: r.Header.Get("X-Storage-Class")}
+		w.Header().Set("ETag", fakeETag(data))
+		w.WriteHeader(http.StatusOK)
+	case http.MethodHead, http.MethodGet:
+		if !exists {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		etag := fakeETag(obj.data)
+		if f.weakETags {
+			etag = "W/" + etag
+		}
+		if r.Method == http.MethodGet && r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+		w.Header().Set("ETag", etag)
+		if obj.storageClass != "" {
+			w.Header().Set("X-Storage-Class", obj.storageClass)
+		}
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			f.fullReads++
+			w.Write(obj.data)
+		}
+	case http.MethodDelete:
+		delete(f.objects, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "unsupported", http.StatusMethodNotAllowed)
+	}
+}
+
+// fakeETag derives an object's ETag from its content
+func fakeETag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// copy answers a server-side copy of the object source into id
+func (f *fakeServer) copy(w http.ResponseWriter, r *http.Request, id, source string) {
+	src, ok := f.objects[source]
+	if !ok {
+		http.Error(w, "no such source", http.StatusNotFound)
+		return
+	}
+
+	dst := fakeObject{data: src.data, storageClass: src.storageClass}
+	if class := r.Header.Get("X-Storage-Class"); class != "" {
+		dst.storageClass = class
+	}
+	if f.corruptCopies {
+		dst.data = append([]byte("!"), src.data[1:]...)
+	}
+	f.objects[id] = dst
+
+	xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"CopyObjectResult"`
+		ETag    string   `xml:"ETag"`
+	}{ETag: fakeETag(dst.data)})
+}
+
+// list answers a listing of bucket, grouping keys by the delimiter if given.
+// With pageSize set, listings are truncated after that many entries and
+// resume after the marker, without a NextMarker.
+func (f *fakeServer) list(w http.ResponseWriter, r *http.Request, bucket string) {
+	query := r.URL.Query()
+	prefix, delimiter, marker := query.Get("prefix"), query.Get("delimiter"), query.Get("marker")
+
+	result := ListBucketResult{Name: bucket, Prefix: prefix, Delimiter: delimiter}
+	seen := make(map[string]bool)
+	for _, id := range slices.Sorted(maps.Keys(f.objects)) {
+		key, ok := strings.CutPrefix(id, bucket+"/")
+		if !ok || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if marker != "" && (key <= marker || delimiter != "" && strings.HasSuffix(marker, delimiter) && strings.HasPrefix(key, marker)) {
+			continue
+		}
+		if f.pageSize > 0 && len(result.Contents)+len(result.CommonPrefixes) == f.pageSize {
+			result.IsTruncated = true
+			break
+		}
+
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+				common := key[:len(prefix)+i+len(delimiter)]
+				if !seen[common] {
+					seen[common] = true
+					result.CommonPrefixes = append(result.CommonPrefixes, CommonPrefix{Prefix: common})
+				}
+				continue
+			}
+		}
+		result.Contents = append(result.Contents, ObjectInfo{Key: key, Size: int64(len(f.objects[id].data))})
+	}
+	xml.NewEncoder(w).Encode(result)
+}
+
+func TestPutObjectEmptyReader(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := c.PutObject(ctx, "bucket", "empty.txt", strings.NewReader(""), ""); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+
+	info, err := c.HeadObject(ctx, "bucket", "empty.txt")
+	if err != nil {
+		t.Fatalf("HeadObject: %v", err)
+	}
+	if info.Size != 0 {
+		t.Errorf("Size = %d, want 0", info.Size)
+	}
+}
+
+func TestDeleteNoContent(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := c.PutObject(ctx, "bucket", "doomed.txt", strings.NewReader("data"), ""); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+	if err := c.DeleteObject(ctx, "bucket", "doomed.txt"); err != nil {
+		t.Errorf("DeleteObject: %v", err)
+	}
+	if _, err := c.HeadObject(ctx, "bucket", "doomed.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("HeadObject after delete: got %v, want ErrNotFound", err)
+	}
+
+	if err := c.DeleteBucket(ctx, "bucket"); err != nil {
+		t.Errorf("DeleteBucket: %v", err)
+	}
+}
+
+func TestCreateOnlyRejectsExisting(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := c.PutObject(ctx, "bucket", "once.txt", strings.NewReader("first"), "", WithCreateOnly()); err != nil {
+		t.Fatalf("first PutObject: %v", err)
+	}
+
+	_, err := c.PutObject(ctx, "bucket", "once.txt", strings.NewReader("second"), "", WithCreateOnly())
+	if !errors.Is(err, ErrObjectExists) {
+		t.Fatalf("second PutObject: got %v, want ErrObjectExists", err)
+	}
+}
+
+func TestListPrefixesDirectChildren(t *testing.T) {
+	c, fake := newTestClient(t)
+	ctx := context.Background()
+
+	for _, key := range []string{
+		"photos/readme.txt",
+		"photos/2023/jan/a.jpg",
+		"photos/2023/feb/b.jpg",
+		"photos/2024/c.jpg",
+		"videos/d.mp4",
+	} {
+		if _, err := c.PutObject(ctx, "bucket", key, strings.NewReader("x"), ""); err != nil {
+			t.Fatalf("PutObject %s: %v", key, err)
+		}
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"", []string{"photos/", "videos/"}},
+		{"photos", []string{"photos/2023/", "photos/2024/"}},
+		{"photos/2023/", []string{"photos/2023/feb/", "photos/2023/jan/"}},
+		{"videos", []string{}},
+	}
+	// One entry per page makes every prefix arrive on a page of its own
+	for _, pageSize := range []int{0, 1} {
+		fake.pageSize = pageSize
+		for _, tt := range tests {
+			got, err := c.ListPrefixes(ctx, "bucket", tt.path)
+			if err != nil {
+				t.Fatalf("ListPrefixes(%q) with page size %d: %v", tt.path, pageSize, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ListPrefixes(%q) with page size %d = %q, want %q", tt.path, pageSize, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestStorageClassRoundTrip(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := c.PutObject(ctx, "bucket", "archive.tar", strings.NewReader("data"), "", WithStorageClass("COLD")); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+
+	info, err := c.HeadObject(ctx, "bucket", "archive.tar")
+	if err != nil {
+		t.Fatalf("HeadObject: %v", err)
+	}
+	if info.StorageClass != "COLD" {
+		t.Errorf("StorageClass = %q, want %q", info.StorageClass, "COLD")
+	}
+}
+
+func TestWithoutKeyCaseFold(t *testing.T) {
+	c, _ := newTestClientWithOptions(t, ClientOptions{KeyCaseFold: true})
+	ctx := context.Background()
+
+	if _, err := c.PutObject(ctx, "bucket", "Reports/Q1.pdf", strings.NewReader("data"), "", WithoutKeyCaseFold()); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+
+	if _, err := c.HeadObject(ctx, "bucket", "Reports/Q1.pdf"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("folded HeadObject: got %v, want ErrNotFound", err)
+	}
+	if _, err := c.HeadObject(ctx, "bucket", "Reports/Q1.pdf", WithoutKeyCaseFold()); err != nil {
+		t.Errorf("HeadObject WithoutKeyCaseFold: %v", err)
+	}
+
+	prefixes, err := c.ListPrefixes(ctx, "bucket", "", WithoutKeyCaseFold())
+	if err != nil {
+		t.Fatalf("ListPrefixes: %v", err)
+	}
+	if !slices.Equal(prefixes, []string{"Reports/"}) {
+		t.Errorf("ListPrefixes = %q, want [Reports/]", prefixes)
+	}
+}
+
+func TestHeadObjectConsistentManyRetries(t *testing.T) {
+	c, _ := newTestClientWithOptions(t, ClientOptions{
+		ConsistencyRetries: 70,
+		ConsistencyWindow:  time.Millisecond,
+	})
+
+	_, err := c.HeadObjectConsistent(context.Background(), "bucket", "missing.txt")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("HeadObjectConsistent: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestListObjectsMultiAllPages(t *testing.T) {
+	c, fake := newTestClient(t)
+	fake.pageSize = 2
+	ctx := context.Background()
+
+	for _, key := range []string{"logs/1", "logs/2", "logs/3", "logs/4", "logs/5", "tmp/1"} {
+		if _, err := c.PutObject(ctx, "bucket", key, strings.NewReader("x"), ""); err != nil {
+			t.Fatalf("PutObject %s: %v", key, err)
+		}
+	}
+
+	results, err := c.ListObjectsMulti(ctx, "bucket", []string{"logs/", "tmp/"}, 2)
+	if err != nil {
+		t.Fatalf("ListObjectsMulti: %v", err)
+	}
+	if n := len(results["logs/"]); n != 5 {
+		t.Errorf("logs/ listed %d objects, want 5", n)
+	}
+	if n := len(results["tmp/"]); n != 1 {
+		t.Errorf("tmp/ listed %d objects, want 1", n)
+	}
+}
+
+func TestPutObjectRetriesInterruptedUpload(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			// Read part of the body, then drop the connection
+			io.CopyN(io.Discard, r.Body, 1024)
+			conn, _, err := http.NewResponseController(w).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient(ClientOptions{BaseURL: srv.URL, APIKey: "test-key"})
+	data := strings.Repeat("x", 1<<20)
+	if _, err := c.PutObject(context.Background(), "bucket", "big.bin", strings.NewReader(data), ""); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("server saw %d attempts, want 2", attempts)
+	}
+}
+
+// checkOptionsNotAppended calls fn with opt in a slice that has spare
+// capacity and fails if fn wrote an option of its own into that backing array
+func checkOptionsNotAppended(t *testing.T, name string, opt RequestOption, fn func(opts ...RequestOption)) {
+	t.Helper()
+
+	opts := make([]RequestOption, 1, 4)
+	opts[0] = opt
+	fn(opts...)
+	if spare := opts[1:cap(opts)]; slices.ContainsFunc(spare, func(o RequestOption) bool { return o != nil }) {
+		t.Errorf("%s appended to the caller's options", name)
+	}
+}
+
+func TestReplaceObjectKeepsCallerOptions(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	checkOptionsNotAppended(t, "ReplaceObject", WithQueryParam("trace", "1"), func(opts ...RequestOption) {
+		c.ReplaceObject(ctx, "bucket", "doc.txt", strings.NewReader("v2"), "", "etag", opts...)
+	})
+}
+
+func TestGetObjectAttributesKeepsCallerOptions(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	checkOptionsNotAppended(t, "GetObjectAttributes", WithQueryParam("trace", "1"), func(opts ...RequestOption) {
+		c.GetObjectAttributes(ctx, "bucket", "doc.txt", nil, opts...)
+	})
+}
+
+func TestGetObjectFallbackKeepsCallerOptions(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	checkOptionsNotAppended(t, "GetObject with fallback", WithFallbackKey("default.png"), func(opts ...RequestOption) {
+		if body, err := c.GetObject(ctx, "bucket", "avatar.png", opts...); err == nil {
+			body.Close()
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"crypto/rand"
+	"io"
+	"net/http"
+)
+
+// ClientOption adjusts a client derived with Clone
+type ClientOption func(*Client)
+
+// WithDefaultBucket sets the bucket used when a bucket name is empty
+func WithDefaultBucket(bucketName string) ClientOption {
+	return func(c *Client) {
+		c.bucketName = bucketName
+	}
+}
+
+// WithAPIKey sets the API key sent with requests, e.g. per tenant
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithStaticHeader adds a header sent with every request. Headers the
+// client sets on a request itself take precedence.
+func WithStaticHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		c.staticHeaders.Add(key, value)
+	}
+}
+
+// WithKeyCaseFold turns lowercasing of object keys on or off; see
+// ClientOptions.KeyCaseFold
+func WithKeyCaseFold(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.keyCaseFold = enabled
+	}
+}
+
+// Clone returns a copy of the client with opts applied. The copy shares the
+// parent's http.Client and transport, caches and limits, so deriving
+// per-scope clients is cheap. A clone with a different API key or key
+// folding sees other objects, so it gets its own ListCacheTTL cache and an
+// ObjectCache namespace of its own instead of answering from the parent's.
+func (c *Client) Clone(opts ...ClientOption) *Client {
+	clone := *c
+	clone.staticHeaders = c.staticHeaders.Clone()
+	if clone.staticHeaders == nil {
+		clone.staticHeaders = http.Header{}
+	}
+
+	for _, opt := range opts {
+		opt(&clone)
+	}
+
+	if clone.apiKey != c.apiKey || clone.keyCaseFold != c.keyCaseFold {
+		if c.metadata != nil {
+			clone.metadata = newMetadataCache(c.metadata.ttl, c.clock)
+		}
+		if c.cache != nil {
+			clone.cache = &scopedObjectCache{cache: c.cache, scope: rand.Text()}
+		}
+	}
+	return &clone
+}
+
+// scopedObjectCache stores entries of a shared ObjectCache under a private
+// namespace, so a clone never reads copies fetched with other credentials
+type scopedObjectCache struct {
+	cache ObjectCache
+	scope string
+}
+
+// Get implements ObjectCache
+func (s *scopedObjectCache) Get(bucket, key string) (io.ReadCloser, string, bool) {
+	return s.cache.Get(s.scope+"/"+bucket, key)
+}
+
+// Create implements ObjectCache
+func (s *scopedObjectCache) Create(bucket, key, etag string) (ObjectCacheWriter, error) {
+	return s.cache.Create(s.scope+"/"+bucket, key, etag)
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCloneWithAPIKeyDoesNotShareCaches(t *testing.T) {
+	disk, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, fake := newTestClientWithOptions(t, ClientOptions{ListCacheTTL: time.Minute, ObjectCache: disk})
+	ctx := context.Background()
+
+	if _, err := parent.PutObject(ctx, "bucket", "report.pdf", strings.NewReader("tenant a"), ""); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+	if _, err := parent.ListObjects(ctx, "bucket", ""); err != nil {
+		t.Fatalf("ListObjects: %v", err)
+	}
+	body, err := parent.GetObject(ctx, "bucket", "report.pdf")
+	if err != nil {
+		t.Fatalf("GetObject: %v", err)
+	}
+	io.Copy(io.Discard, body)
+	body.Close()
+
+	// Only the caches know the object now
+	fake.mu.Lock()
+	delete(fake.objects, "bucket/report.pdf")
+	fake.mu.Unlock()
+
+	if _, err := parent.HeadObject(ctx, "bucket", "report.pdf"); err != nil {
+		t.Fatalf("parent HeadObject should be served from the listing cache: %v", err)
+	}
+	if _, _, ok := parent.cache.Get("bucket", "report.pdf"); !ok {
+		t.Fatal("parent object cache has no copy")
+	}
+
+	tenant := parent.Clone(WithAPIKey("tenant-b"))
+	if _, err := tenant.HeadObject(ctx, "bucket", "report.pdf"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("clone HeadObject: got %v, want ErrNotFound from the server", err)
+	}
+	if body, _, ok := tenant.cache.Get("bucket", "report.pdf"); ok {
+		body.Close()
+		t.Error("clone object cache returned the parent's copy")
+	}
+
+	// A clone that keeps the credentials keeps sharing
+	scoped := parent.Clone(WithStaticHeader("X-Trace", "1"))
+	if _, err := scoped.HeadObject(ctx, "bucket", "report.pdf"); err != nil {
+		t.Errorf("same-key clone HeadObject: %v", err)
+	}
+}
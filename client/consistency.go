@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	defaultConsistencyRetries = 3
+	defaultConsistencyWindow  = 2 * time.Second
+
+	// maxBackoffDoublings bounds the exponent of the retry backoff
+	maxBackoffDoublings = 30
+)
+
+// GetObjectConsistent is GetObject for reads right after a known write on an
+// eventually-consistent backend: a 404 is retried with jittered backoff.
+// Keep the window short so genuinely missing objects still fail quickly.
+func (c *Client) GetObjectConsistent(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (io.ReadCloser, error) {
+	var body io.ReadCloser
+	err := c.retryNotFound(ctx, func() error {
+		var err error
+		body, err = c.GetObject(ctx, bucketName, objectKey, opts...)
+		return err
+	})
+	return body, err
+}
+
+// HeadObjectConsistent is HeadObject with the same 404 retries as GetObjectConsistent
+func (c *Client) HeadObjectConsistent(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (*ObjectInfo, error) {
+	var info *ObjectInfo
+	err := c.retryNotFound(ctx, func() error {
+		var err error
+		info, err = c.HeadObject(ctx, bucketName, objectKey, opts...)
+		return err
+	})
+	return info, err
+}
+
+// retryNotFound calls fn until it succeeds, fails with anything other than
+// ErrNotFound, or the configured retries are used up. Delays double from a
+// base chosen so their sum stays within the consistency window, and never
+// exceed the window itself.
+func (c *Client) retryNotFound(ctx context.Context, fn func() error) error {
+	retries := c.consistencyRetries
+
+	// Clamp the exponent, as large retry counts overflow the shift and round
+	// the base delay down to zero; the extra retries then wait the full window
+	delay := c.consistencyWindow / time.Duration(1<<min(retries, maxBackoffDoublings)-1)
+	if delay <= 0 {
+		delay = min(time.Millisecond, c.consistencyWindow)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !errors.Is(err, ErrNotFound) || attempt >= retries {
+			return err
+		}
+
+		// Full jitter in [delay/2, delay] spreads out concurrent readers
+		wait := delay/2 + rand.N(delay/2+1)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.clock.After(wait):
+		}
+
+		delay = min(delay*2, c.consistencyWindow)
+	}
+}
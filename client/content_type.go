@@ -0,0 +1,63 @@
+package client
+
+import (
+	"mime"
+	"strings"
+)
+
+// Common content types
+const (
+	ContentTypeOctetStream = "application/octet-stream"
+	ContentTypeJSON        = "application/json"
+	ContentTypeXML         = "application/xml"
+	ContentTypePDF         = "application/pdf"
+	ContentTypeZip         = "application/zip"
+	ContentTypeText        = "text/plain"
+	ContentTypeHTML        = "text/html"
+	ContentTypeCSV         = "text/csv"
+	ContentTypeJPEG        = "image/jpeg"
+	ContentTypePNG         = "image/png"
+	ContentTypeGIF         = "image/gif"
+	ContentTypeWebP        = "image/webp"
+	ContentTypeSVG         = "image/svg+xml"
+	ContentTypeMP4         = "video/mp4"
+	ContentTypeWebM        = "video/webm"
+)
+
+// IsImage reports whether the object has an image/* content type, including SVG
+func (o ObjectInfo) IsImage() bool {
+	return mediaType(o.ContentType) == "image"
+}
+
+// IsVideo reports whether the object has a video/* content type
+func (o ObjectInfo) IsVideo() bool {
+	return mediaType(o.ContentType) == "video"
+}
+
+// IsText reports whether the object is textual: text/* or JSON/XML documents
+func (o ObjectInfo) IsText() bool {
+	full := baseContentType(o.ContentType)
+	if mediaType(full) == "text" {
+		return true
+	}
+	if mediaType(full) != "application" {
+		return false
+	}
+	return full == ContentTypeJSON || full == ContentTypeXML ||
+		strings.HasSuffix(full, "+json") || strings.HasSuffix(full, "+xml")
+}
+
+// baseContentType strips parameters such as charset and lowercases the type
+func baseContentType(contentType string) string {
+	if parsed, _, err := mime.ParseMediaType(contentType); err == nil {
+		return parsed
+	}
+	base, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(base))
+}
+
+// mediaType returns the top-level type, e.g. "image" for "image/png; q=1"
+func mediaType(contentType string) string {
+	top, _, _ := strings.Cut(baseContentType(contentType), "/")
+	return top
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// copySourceHeader names the object a PUT copies from, as /<bucket>/<key>
+const copySourceHeader = "X-Copy-Source"
+
+// copyObjectResult is the response body of a server-side copy
+type copyObjectResult struct {
+	ETag         string    `xml:"ETag"`
+	LastModified time.Time `xml:"LastModified"`
+}
+
+// CopyObject copies an object on the server without downloading it. The
+// destination is written with a PUT naming the source in X-Copy-Source.
+// With WithVerifyCopy both objects are checked afterwards and a differing
+// size or ETag fails with ErrChecksumMismatch. The result's Size comes from
+// X-Object-Size or the verification, and is 0 when neither reports it.
+func (c *Client) CopyObject(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string, opts ...RequestOption) (*UploadResult, error) {
+	o := newRequestOptions(opts)
+	url := c.objectURL(dstBucket, dstKey, o)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set(copySourceHeader, c.copySource(srcBucket, srcKey, o))
+	o.apply(req)
+	c.addAuth(req)
+
+	resp, err := c.do(req)
+	c.metadata.invalidate(c.bucket(dstBucket), dstKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.statusError(resp, "copy object")
+	}
+
+	result := &UploadResult{
+		Key:         dstKey,
+		ETag:        resp.Header.Get("ETag"),
+		ContentType: resp.Header.Get("X-Object-Content-Type"),
+	}
+	if size, err := strconv.ParseInt(resp.Header.Get("X-Object-Size"), 10, 64); err == nil {
+		result.Size = size
+	}
+	if lastModified, err := time.Parse(http.TimeFormat, resp.Header.Get("Last-Modified")); err == nil {
+		result.LastModified = lastModified
+	}
+
+	// Servers may report the new ETag in a CopyObjectResult body instead
+	var body copyObjectResult
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, c.maxBody)).Decode(&body); err == nil {
+		if body.ETag != "" {
+			result.ETag = body.ETag
+		}
+		if !body.LastModified.IsZero() {
+			result.LastModified = body.LastModified
+		}
+	}
+
+	if o.verifyCopy {
+		if err := c.verifyCopy(ctx, srcBucket, srcKey, dstBucket, dstKey, result, o); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// copySource builds the X-Copy-Source value for an object
+func (c *Client) copySource(bucketName, objectKey string, o *requestOptions) string {
+	return "/" + url.PathEscape(c.bucket(bucketName)) + "/" + escapeKey(c.serverKey(objectKey, o))
+}
+
+// verifyCopy compares the ETags of source and destination and fills in the
+// size of the copy. Weak and multipart ETags are not content hashes that
+// survive a copy, so they are not compared.
+func (c *Client) verifyCopy(ctx context.Context, srcBucket, srcKey, dstBucket, dstKey string, result *UploadResult, o *requestOptions) error {
+	var keyOpts []RequestOption
+	if o.noKeyCaseFold {
+		keyOpts = []RequestOption{WithoutKeyCaseFold()}
+	}
+
+	src, err := c.HeadObject(ctx, srcBucket, srcKey, keyOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to verify copy: %w", err)
+	}
+	dst, err := c.HeadObject(ctx, dstBucket, dstKey, keyOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to verify copy: %w", err)
+	}
+	result.Size = dst.Size
+
+	if src.Size != dst.Size {
+		return fmt.Errorf("%w: %s is %d bytes, copy %s is %d bytes", ErrChecksumMismatch, srcKey, src.Size, dstKey, dst.Size)
+	}
+	if src.ETag == "" || dst.ETag == "" || src.WeakETag || dst.WeakETag ||
+		strings.Contains(src.ETag, "-") || strings.Contains(dst.ETag, "-") {
+		return nil
+	}
+	if src.ETag != dst.ETag {
+		return fmt.Errorf("%w: %s has ETag %s, copy %s has %s", ErrChecksumMismatch, srcKey, src.ETag, dstKey, dst.ETag)
+	}
+	return nil
+}
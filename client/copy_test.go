@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCopyObject(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := c.PutObject(ctx, "src", "data/report.csv", strings.NewReader("a,b,c"), ""); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+
+	result, err := c.CopyObject(ctx, "src", "data/report.csv", "dst", "archive/report.csv", WithVerifyCopy())
+	if err != nil {
+		t.Fatalf("CopyObject: %v", err)
+	}
+	if result.Key != "archive/report.csv" || result.Size != 5 || result.ETag == "" {
+		t.Errorf("CopyObject = %+v, want key archive/report.csv, size 5 and an ETag", result)
+	}
+
+	body, err := c.GetObject(ctx, "dst", "archive/report.csv")
+	if err != nil {
+		t.Fatalf("GetObject: %v", err)
+	}
+	defer body.Close()
+	if data, _ := io.ReadAll(body); string(data) != "a,b,c" {
+		t.Errorf("copy holds %q, want %q", data, "a,b,c")
+	}
+}
+
+func TestCopyObjectVerifyMismatch(t *testing.T) {
+	c, fake := newTestClient(t)
+	fake.corruptCopies = true
+	ctx := context.Background()
+
+	if _, err := c.PutObject(ctx, "src", "a.bin", strings.NewReader("payload"), ""); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+
+	if _, err := c.CopyObject(ctx, "src", "a.bin", "dst", "a.bin"); err != nil {
+		t.Errorf("unverified CopyObject: %v", err)
+	}
+	if _, err := c.CopyObject(ctx, "src", "a.bin", "dst", "a.bin", WithVerifyCopy()); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("verified CopyObject: got %v, want ErrChecksumMismatch", err)
+	}
+}
+
+func TestCopyObjectStorageClass(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := c.PutObject(ctx, "bucket", "logs/2023.tar", strings.NewReader("old logs"), ""); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+	if _, err := c.CopyObject(ctx, "bucket", "logs/2023.tar", "bucket", "logs/2023.tar", WithStorageClass("COLD")); err != nil {
+		t.Fatalf("CopyObject: %v", err)
+	}
+
+	info, err := c.HeadObject(ctx, "bucket", "logs/2023.tar")
+	if err != nil {
+		t.Fatalf("HeadObject: %v", err)
+	}
+	if info.StorageClass != "COLD" {
+		t.Errorf("StorageClass = %q, want %q", info.StorageClass, "COLD")
+	}
+}
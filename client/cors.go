@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"encoding/xml"
+)
+
+// CORSConfig represents a bucket's CORS rules
+type CORSConfig struct {
+	XMLName xml.Name   `xml:"CORSConfiguration"`
+	Rules   []CORSRule `xml:"CORSRule"`
+}
+
+// CORSRule represents a single CORS rule
+type CORSRule struct {
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// GetBucketCORS retrieves the CORS configuration of a bucket
+func (c *Client) GetBucketCORS(ctx context.Context, bucketName string, opts ...RequestOption) (*CORSConfig, error) {
+	url := c.bucketURL(bucketName) + "?cors"
+
+	var cfg CORSConfig
+	if err := c.getXML(ctx, url, "get bucket cors", &cfg, opts); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// PutBucketCORS replaces the CORS configuration of a bucket
+func (c *Client) PutBucketCORS(ctx context.Context, bucketName string, cfg *CORSConfig, opts ...RequestOption) error {
+	url := c.bucketURL(bucketName) + "?cors"
+	return c.putXML(ctx, url, "put bucket cors", cfg, opts)
+}
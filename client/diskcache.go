@@ -0,0 +1,241 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ObjectCache stores downloaded objects by bucket, key and ETag. GetObject
+// revalidates a cached copy with If-None-Match and serves it on 304, and
+// fills the cache as fresh objects are read. Implementations must be safe
+// for concurrent use.
+type ObjectCache interface {
+	// Get returns the cached copy of an object and its ETag
+	Get(bucket, key string) (body io.ReadCloser, etag string, ok bool)
+	// Create starts storing a copy of an object with the given ETag, the
+	// response header value including any quotes and W/ prefix
+	Create(bucket, key, etag string) (ObjectCacheWriter, error)
+}
+
+// ObjectCacheWriter receives the data of an object being cached. Commit makes
+// the copy visible to Get; Discard drops a partial copy.
+type ObjectCacheWriter interface {
+	io.Writer
+	Commit() error
+	Discard()
+}
+
+// DiskCache is an ObjectCache that keeps objects as files in a directory and
+// evicts the least recently used ones once their total size exceeds a limit
+type DiskCache struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	entries map[string]*diskCacheEntry
+	size    int64
+}
+
+// diskCacheEntry is a cached file; name is "<hash of bucket/key>.<etag>"
+type diskCacheEntry struct {
+	name     string
+	etag     string
+	size     int64
+	lastUsed time.Time
+}
+
+// NewDiskCache opens or creates a cache in dir holding up to maxSize bytes
+// (unlimited when maxSize <= 0). Files left from earlier runs are reused.
+func NewDiskCache(dir string, maxSize int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	d := &DiskCache{dir: dir, maxSize: maxSize, entries: make(map[string]*diskCacheEntry)}
+	for _, file := range files {
+		id, encodedETag, ok := strings.Cut(file.Name(), ".")
+		if !ok || file.IsDir() || strings.HasPrefix(file.Name(), ".tmp-") {
+			continue
+		}
+		etag, err := base64.RawURLEncoding.DecodeString(encodedETag)
+		info, infoErr := file.Info()
+		if err != nil || infoErr != nil {
+			continue
+		}
+		d.entries[id] = &diskCacheEntry{name: file.Name(), etag: string(etag), size: info.Size(), lastUsed: info.ModTime()}
+		d.size += info.Size()
+	}
+
+	d.mu.Lock()
+	d.evict()
+	d.mu.Unlock()
+
+	return d, nil
+}
+
+// cacheID derives a file name prefix from bucket and key
+func cacheID(bucket, key string) string {
+	sum := sha256.Sum256([]byte(bucket + "/" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get implements ObjectCache
+func (d *DiskCache) Get(bucket, key string) (io.ReadCloser, string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry, ok := d.entries[cacheID(bucket, key)]
+	if !ok {
+		return nil, "", false
+	}
+
+	file, err := os.Open(filepath.Join(d.dir, entry.name))
+	if err != nil {
+		d.remove(cacheID(bucket, key))
+		return nil, "", false
+	}
+	entry.lastUsed = time.Now()
+	return file, entry.etag, true
+}
+
+// Create implements ObjectCache
+func (d *DiskCache) Create(bucket, key, etag string) (ObjectCacheWriter, error) {
+	file, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache file: %w", err)
+	}
+	return &diskCacheWriter{cache: d, file: file, id: cacheID(bucket, key), etag: etag}, nil
+}
+
+// remove deletes an entry and its file; d.mu must be held
+func (d *DiskCache) remove(id string) {
+	entry, ok := d.entries[id]
+	if !ok {
+		return
+	}
+	os.Remove(filepath.Join(d.dir, entry.name))
+	d.size -= entry.size
+	delete(d.entries, id)
+}
+
+// evict removes the least recently used entries until the cache fits; d.mu must be held
+func (d *DiskCache) evict() {
+	if d.maxSize <= 0 || d.size <= d.maxSize {
+		return
+	}
+
+	ids := make([]string, 0, len(d.entries))
+	for id := range d.entries {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b string) int {
+		return d.entries[a].lastUsed.Compare(d.entries[b].lastUsed)
+	})
+
+	for _, id := range ids {
+		if d.size <= d.maxSize {
+			break
+		}
+		d.remove(id)
+	}
+}
+
+// diskCacheWriter writes a new copy to a temporary file
+type diskCacheWriter struct {
+	cache *DiskCache
+	file  *os.File
+	id    string
+	etag  string
+	size  int64
+}
+
+func (w *diskCacheWriter) Write(p []byte) (int, error) {
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Commit moves the copy into place, replacing any older version of the object
+func (w *diskCacheWriter) Commit() error {
+	if err := w.file.Close(); err != nil {
+		os.Remove(w.file.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	d := w.cache
+	name := w.id + "." + base64.RawURLEncoding.EncodeToString([]byte(w.etag))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.remove(w.id)
+	if err := os.Rename(w.file.Name(), filepath.Join(d.dir, name)); err != nil {
+		os.Remove(w.file.Name())
+		return fmt.Errorf("failed to store cache file: %w", err)
+	}
+	d.entries[w.id] = &diskCacheEntry{name: name, etag: w.etag, size: w.size, lastUsed: time.Now()}
+	d.size += w.size
+	d.evict()
+
+	return nil
+}
+
+// Discard drops the partial copy
+func (w *diskCacheWriter) Discard() {
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
+
+// cachingBody copies an object into the cache while it is read and commits
+// the copy once the body is read to the end
+type cachingBody struct {
+	io.ReadCloser
+	w    ObjectCacheWriter
+	done bool
+}
+
+func (b *cachingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if b.done {
+		return n, err
+	}
+
+	if n > 0 {
+		if _, werr := b.w.Write(p[:n]); werr != nil {
+			b.w.Discard()
+			b.done = true
+			return n, err
+		}
+	}
+	if err == io.EOF {
+		// A failed commit only costs a future cache hit
+		_ = b.w.Commit()
+		b.done = true
+	} else if err != nil {
+		b.w.Discard()
+		b.done = true
+	}
+	return n, err
+}
+
+func (b *cachingBody) Close() error {
+	if !b.done {
+		b.w.Discard()
+		b.done = true
+	}
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestObjectCacheWeakETag(t *testing.T) {
+	disk, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, fake := newTestClientWithOptions(t, ClientOptions{ObjectCache: disk})
+	fake.weakETags = true
+	ctx := context.Background()
+
+	if _, err := c.PutObject(ctx, "bucket", "page.html", strings.NewReader("<html>"), ""); err != nil {
+		t.Fatalf("PutObject: %v", err)
+	}
+
+	for i := range 2 {
+		body, err := c.GetObject(ctx, "bucket", "page.html")
+		if err != nil {
+			t.Fatalf("GetObject %d: %v", i, err)
+		}
+		data, err := io.ReadAll(body)
+		body.Close()
+		if err != nil || string(data) != "<html>" {
+			t.Fatalf("GetObject %d = %q, %v", i, data, err)
+		}
+	}
+
+	if fake.fullReads != 1 {
+		t.Errorf("server sent the body %d times, want 1 with the second read revalidated", fake.fullReads)
+	}
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// EncodedReader is the body GetObject returns with WithRawEncoding, still in
+// the encoding the server sent
+type EncodedReader struct {
+	io.ReadCloser
+	// ContentEncoding is the response's Content-Encoding, e.g. "br"; empty
+	// when the body is not encoded
+	ContentEncoding string
+}
+
+// decodedBody closes both the decoder and the underlying body
+type decodedBody struct {
+	io.Reader
+	decoder io.Closer
+	body    io.Closer
+}
+
+func (b *decodedBody) Close() error {
+	b.decoder.Close()
+	return b.body.Close()
+}
+
+// decodeBody undoes a gzip or deflate Content-Encoding. Other encodings, such
+// as br, cannot be decoded with the standard library; the body is closed and
+// an error matching ErrUnsupportedEncoding returned rather than handing back
+// compressed data the caller cannot tell apart.
+func decodeBody(body io.ReadCloser, contentEncoding string) (io.ReadCloser, error) {
+	var decoder io.ReadCloser
+	var err error
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "gzip", "x-gzip":
+		decoder, err = gzip.NewReader(body)
+	case "deflate":
+		// The HTTP deflate coding is the zlib format
+		decoder, err = zlib.NewReader(body)
+	case "", "identity":
+		return body, nil
+	default:
+		body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, contentEncoding)
+	}
+	if err != nil {
+		body.Close()
+		return nil, fmt.Errorf("failed to decode %s body: %w", contentEncoding, err)
+	}
+
+	return &decodedBody{Reader: decoder, decoder: decoder, body: body}, nil
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestDecodeBody(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte("hello"))
+	zw.Close()
+
+	tests := []struct {
+		encoding string
+		body     []byte
+		want     string
+		wantErr  error
+	}{
+		{"", []byte("hello"), "hello", nil},
+		{"identity", []byte("hello"), "hello", nil},
+		{"gzip", gz.Bytes(), "hello", nil},
+		{"br", []byte{0x0b, 0x02, 0x80}, "", ErrUnsupportedEncoding},
+	}
+	for _, tt := range tests {
+		body, err := decodeBody(io.NopCloser(bytes.NewReader(tt.body)), tt.encoding)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("decodeBody(%q): got error %v, want %v", tt.encoding, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		got, err := io.ReadAll(body)
+		body.Close()
+		if err != nil || string(got) != tt.want {
+			t.Errorf("decodeBody(%q) = %q, %v; want %q", tt.encoding, got, err, tt.want)
+		}
+	}
+}
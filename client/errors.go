@@ -0,0 +1,199 @@
+package client
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ErrEmptyPrefix is returned by prefix-wide operations called with an empty
+// prefix, which would otherwise affect the whole bucket
+var ErrEmptyPrefix = errors.New("empty prefix would match the whole bucket; use WithAllowEmptyPrefix to confirm")
+
+// ErrNotFound is matched by errors for requests the server answered with 404
+var ErrNotFound = errors.New("not found")
+
+// ErrPreconditionFailed is matched by errors for conditional requests the
+// server rejected with 412, e.g. because the ETag no longer matches
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// ErrNotModified is matched by errors for conditional requests the server
+// answered with 304, e.g. HeadObject with WithIfModifiedSince
+var ErrNotModified = errors.New("not modified")
+
+// ErrObjectExists is returned by uploads using WithCreateOnly when the object already exists
+var ErrObjectExists = errors.New("object already exists")
+
+// ErrObjectChanged is returned when a resumed download finds that the object
+// no longer matches the version that was being read
+var ErrObjectChanged = errors.New("object changed during download")
+
+// ErrObjectLocked is matched by errors for deletes or overwrites the server
+// rejected with 423 because the object is under retention or legal hold
+var ErrObjectLocked = errors.New("object is locked by retention or legal hold")
+
+// ErrQuotaExceeded is matched by errors for uploads the server rejected with
+// 507 because the bucket quota is used up
+var ErrQuotaExceeded = errors.New("bucket quota exceeded")
+
+// ErrPartialWriteUnsupported is returned by PutObjectRange when the server
+// does not support overwriting a byte range of an object
+var ErrPartialWriteUnsupported = errors.New("server does not support partial writes")
+
+// ErrEntityTooLarge is matched by errors for uploads the server rejected with
+// 413 because they exceed its size limit; see StatusError.MaxSize
+var ErrEntityTooLarge = errors.New("request entity too large")
+
+// ErrUnsupportedEncoding is returned by GetObject with WithAcceptEncoding when
+// the server answers in an encoding it cannot decode, such as br; use
+// WithRawEncoding to receive such bodies as sent
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// ErrChecksumMismatch is returned by CopyObject with WithVerifyCopy when the
+// copy does not match its source
+var ErrChecksumMismatch = errors.New("copy does not match source")
+
+// ErrObjectTooLarge is returned by GetObjectBytes for objects over its size limit
+var ErrObjectTooLarge = errors.New("object too large to buffer")
+
+// ErrUploadInterrupted is matched by errors for uploads whose connection
+// failed after part of the body was sent; see UploadInterruptedError.
+// PutObject retries such failures once before returning them.
+var ErrUploadInterrupted = errors.New("upload interrupted")
+
+// UploadInterruptedError reports how much of an upload body was sent before
+// the connection failed. It matches ErrUploadInterrupted and the cause.
+type UploadInterruptedError struct {
+	BytesSent int64
+	Err       error
+}
+
+// Error implements the error interface
+func (e *UploadInterruptedError) Error() string {
+	return fmt.Sprintf("upload interrupted after %d bytes: %v", e.BytesSent, e.Err)
+}
+
+// Unwrap returns ErrUploadInterrupted and the underlying error
+func (e *UploadInterruptedError) Unwrap() []error {
+	return []error{ErrUploadInterrupted, e.Err}
+}
+
+// uploadError wraps a failed upload request, marking it interrupted when
+// part of the body had already been sent
+func uploadError(err error, sent int64) error {
+	if sent > 0 {
+		err = &UploadInterruptedError{BytesSent: sent, Err: err}
+	}
+	return fmt.Errorf("failed to make request: %w", err)
+}
+
+// ErrNoObjects is returned by ListObjects with WithErrorOnEmpty when nothing matches
+var ErrNoObjects = errors.New("no objects found")
+
+// StatusError is returned when the server answers with an unexpected status.
+// Use errors.Is with the sentinel errors above to branch on common statuses.
+type StatusError struct {
+	Op         string
+	StatusCode int
+	Body       string
+
+	// Response is the structured error decoded from Body, or nil when the
+	// body is not a JSON or XML error document
+	Response *ErrorResponse
+
+	// MaxSize is the size limit in bytes the server advertised with a 413
+	// in X-Max-Object-Size, or 0 when it did not
+	MaxSize int64
+}
+
+// ErrorResponse is the error document the server returns, as JSON or XML
+// depending on the endpoint
+type ErrorResponse struct {
+	XMLName   xml.Name `xml:"Error" json:"-"`
+	Code      string   `xml:"Code" json:"code"`
+	Message   string   `xml:"Message" json:"message"`
+	Resource  string   `xml:"Resource" json:"resource,omitempty"`
+	RequestID string   `xml:"RequestId" json:"requestId,omitempty"`
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	msg := e.Body
+	if r := e.Response; r != nil {
+		msg = r.Message
+		if r.Code != "" {
+			msg = r.Code + ": " + r.Message
+		}
+	}
+	if e.MaxSize > 0 {
+		return fmt.Sprintf("failed to %s: %s (status: %d, max size: %d bytes)", e.Op, msg, e.StatusCode, e.MaxSize)
+	}
+	return fmt.Sprintf("failed to %s: %s (status: %d)", e.Op, msg, e.StatusCode)
+}
+
+// Is maps the status code onto the package's sentinel errors
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrPreconditionFailed:
+		return e.StatusCode == http.StatusPreconditionFailed
+	case ErrNotModified:
+		return e.StatusCode == http.StatusNotModified
+	case ErrObjectLocked:
+		return e.StatusCode == http.StatusLocked
+	case ErrQuotaExceeded:
+		return e.StatusCode == http.StatusInsufficientStorage
+	case ErrEntityTooLarge:
+		return e.StatusCode == http.StatusRequestEntityTooLarge
+	}
+	return false
+}
+
+// statusError builds a StatusError for a failed response, reading a bounded
+// amount of the body for the message
+func (c *Client) statusError(resp *http.Response, op string) error {
+	body := c.readErrorBody(resp)
+	err := &StatusError{
+		Op:         op,
+		StatusCode: resp.StatusCode,
+		Body:       body,
+		Response:   parseErrorResponse(resp.Header.Get("Content-Type"), body),
+	}
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		err.MaxSize, _ = strconv.ParseInt(resp.Header.Get("X-Max-Object-Size"), 10, 64)
+	}
+	return err
+}
+
+// isNoSuchBucket reports whether err is a 404 caused by a missing bucket.
+// Without an error code, a 404 on an upload can only mean the bucket.
+func isNoSuchBucket(err error) bool {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		return false
+	}
+	return statusErr.Response == nil || statusErr.Response.Code == "" || statusErr.Response.Code == "NoSuchBucket"
+}
+
+// parseErrorResponse decodes an error body according to its content type,
+// returning nil for other content or documents without a code or message
+func parseErrorResponse(contentType, body string) *ErrorResponse {
+	var r ErrorResponse
+	var err error
+	switch baseContentType(contentType) {
+	case "application/json", "application/problem+json":
+		err = json.Unmarshal([]byte(body), &r)
+	case "application/xml", "text/xml":
+		err = xml.Unmarshal([]byte(body), &r)
+	default:
+		return nil
+	}
+	if err != nil || (r.Code == "" && r.Message == "") {
+		return nil
+	}
+	return &r
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// ComputeETag computes the ETag the server assigns to the data in r, without
+// quotes, so a local file can be compared with an object before uploading.
+//
+// With partSize <= 0 it is the hex MD5 of the data, as for PutObject. With a
+// positive partSize it is the ETag of a multipart upload that split the data
+// into parts of partSize bytes (the last part may be shorter): the hex MD5 of
+// the concatenated binary MD5s of the parts, followed by "-" and the number
+// of parts. Empty data uploaded in parts counts as a single empty part.
+func ComputeETag(r io.Reader, partSize int64) (string, error) {
+	if partSize <= 0 {
+		h := md5.New()
+		if _, err := io.Copy(h, r); err != nil {
+			return "", fmt.Errorf("failed to read data: %w", err)
+		}
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	composite := md5.New()
+	parts := 0
+	for {
+		h := md5.New()
+		n, err := io.CopyN(h, r, partSize)
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("failed to read part %d: %w", parts+1, err)
+		}
+		if n == 0 && parts > 0 {
+			break
+		}
+		composite.Write(h.Sum(nil))
+		parts++
+		if n < partSize {
+			break
+		}
+	}
+
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(composite.Sum(nil)), parts), nil
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"io"
+	"sync"
+)
+
+// Transfer operations reported to an EventHook
+const (
+	OpPutObject       = "PutObject"
+	OpPutObjectStream = "PutObjectStream"
+	OpGetObject       = "GetObject"
+	OpGetObjectRange  = "GetObjectRange"
+	OpUploadPart      = "UploadPart"
+)
+
+// EventHook receives lifecycle events for uploads and downloads, e.g. to
+// drive a progress UI across many transfers. Methods may be called
+// concurrently and should return quickly.
+type EventHook interface {
+	// OnTransferStart is called before a transfer begins; size is -1 when unknown
+	OnTransferStart(op, bucket, key string, size int64)
+	// OnTransferEnd is called once per started transfer with the bytes moved
+	// and the error it failed with, if any. Downloads end when the body is
+	// fully read or closed.
+	OnTransferEnd(op, bucket, key string, transferred int64, err error)
+}
+
+// transferStart reports the start of a transfer to the event hook
+func (c *Client) transferStart(op, bucketName, objectKey string, size int64) {
+	if c.events != nil {
+		c.events.OnTransferStart(op, bucketName, objectKey, size)
+	}
+}
+
+// transferEnd reports the end of a transfer to the event hook
+func (c *Client) transferEnd(op, bucketName, objectKey string, transferred int64, err error) {
+	if c.events != nil {
+		c.events.OnTransferEnd(op, bucketName, objectKey, transferred, err)
+	}
+}
+
+// uploadEnd reports the end of an upload from its result
+func (c *Client) uploadEnd(op, bucketName, objectKey string, result *UploadResult, err error) {
+	var transferred int64
+	if result != nil {
+		transferred = result.Size
+	}
+	c.transferEnd(op, bucketName, objectKey, transferred, err)
+}
+
+// trackDownload wraps a download body so its end is reported to the event hook
+func (c *Client) trackDownload(op, bucketName, objectKey string, body io.ReadCloser) io.ReadCloser {
+	if c.events == nil {
+		return body
+	}
+
+	return &trackedBody{
+		ReadCloser: body,
+		end: func(n int64, err error) {
+			c.transferEnd(op, bucketName, objectKey, n, err)
+		},
+	}
+}
+
+// trackedBody counts bytes read and reports the end of the transfer once
+type trackedBody struct {
+	io.ReadCloser
+	end func(n int64, err error)
+
+	n    int64
+	once sync.Once
+}
+
+func (b *trackedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+
+	if err == io.EOF {
+		b.once.Do(func() { b.end(b.n, nil) })
+	} else if err != nil {
+		b.once.Do(func() { b.end(b.n, err) })
+	}
+	return n, err
+}
+
+func (b *trackedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.end(b.n, nil) })
+	return err
+}
+
+// readerSize returns the length of readers that know it, or -1
+func readerSize(r io.Reader) int64 {
+	switch sized := r.(type) {
+	case interface{ Len() int }:
+		return int64(sized.Len())
+	case *io.SectionReader:
+		return sized.Size()
+	}
+	return -1
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// KeyFromPath builds an object key from a local file path.
+// The path is made relative to baseDir, OS separators (including Windows
+// backslashes) become forward slashes, and the result is joined under prefix.
+func KeyFromPath(prefix, localPath, baseDir string) string {
+	key := toSlash(localPath)
+
+	if base := strings.TrimSuffix(toSlash(baseDir), "/"); base != "" && base != "." {
+		if key == base {
+			key = ""
+		} else {
+			key = strings.TrimPrefix(key, base+"/")
+		}
+	}
+
+	// Cleaning against a root keeps ".." segments from escaping the prefix
+	key = strings.TrimPrefix(path.Clean("/"+key), "/")
+
+	prefix = strings.Trim(toSlash(prefix), "/")
+	if prefix == "" {
+		return key
+	}
+	if key == "" {
+		return prefix
+	}
+	return prefix + "/" + key
+}
+
+// toSlash converts both Unix and Windows separators to forward slashes
+func toSlash(p string) string {
+	return strings.ReplaceAll(p, `\`, "/")
+}
+
+// escapeKey percent-encodes each segment of an object key while keeping the
+// slashes that separate them
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
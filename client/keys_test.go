@@ -0,0 +1,32 @@
+package client
+
+import "testing"
+
+func TestKeyFromPath(t *testing.T) {
+	tests := []struct {
+		name                       string
+		prefix, localPath, baseDir string
+		want                       string
+	}{
+		{"unix", "backup", "/data/photos/a.jpg", "/data", "backup/photos/a.jpg"},
+		{"windows backslashes", "backup", `C:\data\photos\a.jpg`, `C:\data`, "backup/photos/a.jpg"},
+		{"windows base with trailing separator", "backup", `C:\data\a.jpg`, `C:\data\`, "backup/a.jpg"},
+		{"mixed separators", "", `C:\data/photos\a.jpg`, `C:/data`, "photos/a.jpg"},
+		{"backslash prefix", `backup\2024\`, `C:\data\a.jpg`, `C:\data`, "backup/2024/a.jpg"},
+		{"dot-dot segments", "backup", "/data/photos/../../etc/passwd", "/data", "backup/etc/passwd"},
+		{"windows dot-dot segments", "backup", `C:\data\..\..\secret.txt`, `C:\data`, "backup/secret.txt"},
+		{"empty prefix", "", "/data/photos/a.jpg", "/data", "photos/a.jpg"},
+		{"path equal to base", "backup", "/data", "/data", "backup"},
+		{"windows path equal to base", "backup", `C:\data`, `C:\data\`, "backup"},
+		{"path equal to base without prefix", "", "/data", "/data", ""},
+		{"empty base", "backup", "photos/a.jpg", "", "backup/photos/a.jpg"},
+		{"path outside base", "backup", "/other/a.jpg", "/data", "backup/other/a.jpg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KeyFromPath(tt.prefix, tt.localPath, tt.baseDir); got != tt.want {
+				t.Errorf("KeyFromPath(%q, %q, %q) = %q, want %q", tt.prefix, tt.localPath, tt.baseDir, got, tt.want)
+			}
+		})
+	}
+}
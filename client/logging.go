@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// sensitiveQueryParams lists query parameter fragments whose values are redacted in logs
+var sensitiveQueryParams = []string{"signature", "sig", "token", "credential", "key", "policy"}
+
+// logRequest writes a single log record for a completed request.
+// Header values are never logged, so Authorization and X-API-Key cannot leak.
+func (c *Client) logRequest(req *http.Request, resp *http.Response, err error, latency time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	ctx := req.Context()
+	if !c.logger.Enabled(ctx, c.logLevel) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", sanitizeURL(req.URL)),
+		slog.Duration("latency", latency),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", sanitizeError(err)))
+	}
+
+	c.logger.LogAttrs(context.WithoutCancel(ctx), c.logLevel, "gtm-storage request", attrs...)
+}
+
+// sanitizeURL returns the URL with user info removed and signature-like query values redacted
+func sanitizeURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	clean := *u
+	clean.User = nil
+
+	if clean.RawQuery != "" {
+		query := clean.Query()
+		for name := range query {
+			if isSensitiveParam(name) {
+				query.Set(name, "REDACTED")
+			}
+		}
+		clean.RawQuery = query.Encode()
+	}
+
+	return clean.String()
+}
+
+// sanitizeError returns the error text with the URL of a *url.Error sanitized,
+// as transport errors embed the full request URL
+func sanitizeError(err error) string {
+	var uerr *url.Error
+	if !errors.As(err, &uerr) {
+		return err.Error()
+	}
+
+	clean := *uerr
+	if u, perr := url.Parse(uerr.URL); perr == nil {
+		clean.URL = sanitizeURL(u)
+	} else {
+		clean.URL = "REDACTED"
+	}
+	return strings.Replace(err.Error(), uerr.Error(), clean.Error(), 1)
+}
+
+// isSensitiveParam reports whether a query parameter may carry a credential or signature
+func isSensitiveParam(name string) bool {
+	lower := strings.ToLower(name)
+	for _, fragment := range sensitiveQueryParams {
+		if strings.Contains(lower, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ManifestParser decodes a manifest object into the object keys it references
+type ManifestParser func(r io.Reader) ([]string, error)
+
+// ParseJSONManifest is the default ManifestParser. It accepts a JSON array of
+// keys, or an object whose "keys" field is such an array.
+func ParseJSONManifest(r io.Reader) ([]string, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	var keys []string
+	if err := json.Unmarshal(raw, &keys); err == nil {
+		return keys, nil
+	}
+
+	var wrapped struct {
+		Keys []string `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &wrapped); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	return wrapped.Keys, nil
+}
+
+// GetManifestObjects fetches the manifest object manifestKey and returns the
+// keys it references, decoded by parse (ParseJSONManifest when nil). The
+// manifest is read with GetObjectBytes, so one larger than WithMaxBytes
+// (default 32MB) fails with ErrObjectTooLarge.
+func (c *Client) GetManifestObjects(ctx context.Context, bucketName, manifestKey string, parse ManifestParser, opts ...RequestOption) ([]string, error) {
+	if parse == nil {
+		parse = ParseJSONManifest
+	}
+
+	data, err := c.GetObjectBytes(ctx, bucketName, manifestKey, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", manifestKey, err)
+	}
+	return keys, nil
+}
+
+// FetchManifestObjects fetches the objects referenced by a manifest with up
+// to concurrency parallel downloads and passes each body to fn, which may be
+// called concurrently. Errors for individual keys are joined.
+func (c *Client) FetchManifestObjects(ctx context.Context, bucketName, manifestKey string, parse ManifestParser, concurrency int, fn func(key string, body io.Reader) error, opts ...RequestOption) error {
+	keys, err := c.GetManifestObjects(ctx, bucketName, manifestKey, parse, opts...)
+	if err != nil {
+		return err
+	}
+
+	return c.forEachTransfer(ctx, keys, concurrency, func(key string) error {
+		body, err := c.GetObject(ctx, bucketName, key, opts...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		defer body.Close()
+
+		if err := fn(key, body); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// metadataCache remembers object metadata from ListObjects so that HeadObject
+// calls for the same keys shortly afterwards can be answered locally
+type metadataCache struct {
+	ttl   time.Duration
+	clock Clock
+
+	mu      sync.Mutex
+	entries map[string]metadataCacheEntry
+}
+
+// metadataCacheEntry is a cached object with its expiry
+type metadataCacheEntry struct {
+	info    ObjectInfo
+	expires time.Time
+}
+
+// newMetadataCache returns nil, i.e. a disabled cache, when ttl is not positive
+func newMetadataCache(ttl time.Duration, clock Clock) *metadataCache {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return &metadataCache{
+		ttl:     ttl,
+		clock:   clock,
+		entries: make(map[string]metadataCacheEntry),
+	}
+}
+
+// get returns the cached metadata for an object if it has not expired
+func (m *metadataCache) get(bucketName, objectKey string) (*ObjectInfo, bool) {
+	if m == nil {
+		return nil, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := bucketName + "/" + objectKey
+	entry, ok := m.entries[id]
+	if !ok {
+		return nil, false
+	}
+	if m.clock.Now().After(entry.expires) {
+		delete(m.entries, id)
+		return nil, false
+	}
+
+	info := entry.info
+	return &info, true
+}
+
+// store caches the listed objects and drops any expired entries
+func (m *metadataCache) store(bucketName string, objects []ObjectInfo) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	for id, entry := range m.entries {
+		if now.After(entry.expires) {
+			delete(m.entries, id)
+		}
+	}
+
+	expires := now.Add(m.ttl)
+	for _, obj := range objects {
+		// Listings carry the raw ETag header value; normalize it as HeadObject does
+		obj.ETag, obj.WeakETag = parseETag(obj.ETag)
+		m.entries[bucketName+"/"+obj.Key] = metadataCacheEntry{info: obj, expires: expires}
+	}
+}
+
+// invalidate forgets an object after it was written or deleted
+func (m *metadataCache) invalidate(bucketName, objectKey string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, bucketName+"/"+objectKey)
+}
@@ -0,0 +1,353 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MultipartUpload is an in-progress multipart upload.
+// Parts may be uploaded concurrently and in any order; Complete assembles
+// them by part number.
+type MultipartUpload struct {
+	client     *Client
+	bucketName string
+	objectKey  string
+	uploadID   string
+
+	// noKeyCaseFold keeps WithoutKeyCaseFold from the start for every part
+	noKeyCaseFold bool
+
+	mu    sync.Mutex
+	parts map[int]uploadedPart
+}
+
+// uploadedPart records a part the server accepted
+type uploadedPart struct {
+	etag string
+	size int64
+}
+
+// initiateMultipartUploadResult is the response to starting a multipart upload
+type initiateMultipartUploadResult struct {
+	Bucket   string `xml:"Bucket"`
+	Key      string `xml:"Key"`
+	UploadID string `xml:"UploadId"`
+}
+
+// completeMultipartUpload is the request body listing the parts to assemble
+type completeMultipartUpload struct {
+	XMLName xml.Name       `xml:"CompleteMultipartUpload"`
+	Parts   []completePart `xml:"Part"`
+}
+
+// completePart identifies one part in a completion request
+type completePart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// completeMultipartUploadResult is the response to completing a multipart upload
+type completeMultipartUploadResult struct {
+	Location string `xml:"Location"`
+	Key      string `xml:"Key"`
+	ETag     string `xml:"ETag"`
+}
+
+// NewMultipartUpload starts a multipart upload for an object
+func (c *Client) NewMultipartUpload(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (*MultipartUpload, error) {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o) + "?uploads"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	o.apply(req)
+	c.addAuth(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.statusError(resp, "initiate multipart upload")
+	}
+
+	var result initiateMultipartUploadResult
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.UploadID == "" {
+		return nil, fmt.Errorf("failed to initiate multipart upload: no upload id in response")
+	}
+
+	upload := c.resumeMultipartUpload(bucketName, objectKey, result.UploadID)
+	upload.noKeyCaseFold = o.noKeyCaseFold
+	return upload, nil
+}
+
+// resumeMultipartUpload returns a handle for an existing upload ID
+func (c *Client) resumeMultipartUpload(bucketName, objectKey, uploadID string) *MultipartUpload {
+	return &MultipartUpload{
+		client:     c,
+		bucketName: bucketName,
+		objectKey:  objectKey,
+		uploadID:   uploadID,
+		parts:      make(map[int]uploadedPart),
+	}
+}
+
+// keyOptions returns the options that map the upload's key like its start did
+func (u *MultipartUpload) keyOptions() *requestOptions {
+	return &requestOptions{noKeyCaseFold: u.noKeyCaseFold}
+}
+
+// UploadID returns the server-assigned ID of the upload
+func (u *MultipartUpload) UploadID() string {
+	return u.uploadID
+}
+
+// UploadPart uploads one part. Part numbers start at 1; uploading the same
+// number again replaces the earlier part.
+func (u *MultipartUpload) UploadPart(ctx context.Context, partNum int, r io.Reader) (err error) {
+	if partNum < 1 {
+		return fmt.Errorf("invalid part number %d: must be at least 1", partNum)
+	}
+
+	c := u.client
+	counter := &countingReader{r: r}
+
+	c.transferStart(OpUploadPart, u.bucketName, u.objectKey, readerSize(r))
+	defer func() { c.transferEnd(OpUploadPart, u.bucketName, u.objectKey, counter.n.Load(), err) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.url(fmt.Sprintf("partNumber=%d", partNum)), counter)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Keep a known length so the transport does not fall back to chunked encoding
+	if size := readerSize(r); size >= 0 {
+		req.ContentLength = size
+	}
+	req.Header.Set("Content-Type", ContentTypeOctetStream)
+	c.addAuth(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.statusError(resp, fmt.Sprintf("upload part %d", partNum))
+	}
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		return fmt.Errorf("failed to upload part %d: no etag in response", partNum)
+	}
+
+	u.mu.Lock()
+	u.parts[partNum] = uploadedPart{etag: etag, size: counter.n.Load()}
+	u.mu.Unlock()
+
+	return nil
+}
+
+// Complete assembles the uploaded parts into the final object
+func (u *MultipartUpload) Complete(ctx context.Context) (*UploadResult, error) {
+	c := u.client
+
+	u.mu.Lock()
+	body := completeMultipartUpload{}
+	var size int64
+	for _, num := range slices.Sorted(maps.Keys(u.parts)) {
+		part := u.parts[num]
+		body.Parts = append(body.Parts, completePart{PartNumber: num, ETag: part.etag})
+		size += part.size
+	}
+	u.mu.Unlock()
+
+	if len(body.Parts) == 0 {
+		return nil, fmt.Errorf("failed to complete multipart upload: no parts uploaded")
+	}
+
+	data, err := xml.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.url(""), bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/xml")
+	c.addAuth(req)
+
+	resp, err := c.do(req)
+	c.metadata.invalidate(c.bucket(u.bucketName), u.objectKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.statusError(resp, "complete multipart upload")
+	}
+
+	var result completeMultipartUploadResult
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &UploadResult{
+		Key:  u.objectKey,
+		ETag: strings.Trim(result.ETag, "\""),
+		Size: size,
+	}, nil
+}
+
+// Abort cancels the upload and discards any uploaded parts
+func (u *MultipartUpload) Abort(ctx context.Context) error {
+	// A fresh slice, so no caller's options can be appended to
+	var opts []RequestOption
+	if u.noKeyCaseFold {
+		opts = []RequestOption{WithoutKeyCaseFold()}
+	}
+	return u.client.AbortMultipartUpload(ctx, u.bucketName, u.objectKey, u.uploadID, opts...)
+}
+
+// AbortMultipartUpload cancels an upload by ID and discards its parts
+func (c *Client) AbortMultipartUpload(ctx context.Context, bucketName, objectKey, uploadID string, opts ...RequestOption) error {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o) + "?uploadId=" + url.QueryEscape(uploadID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	o.apply(req)
+	c.addAuth(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return c.statusError(resp, "abort multipart upload")
+	}
+
+	return nil
+}
+
+// MultipartUploadInfo describes an incomplete multipart upload
+type MultipartUploadInfo struct {
+	Key       string    `xml:"Key"`
+	UploadID  string    `xml:"UploadId"`
+	Initiated time.Time `xml:"Initiated"`
+}
+
+// listMultipartUploadsResult is the response to listing multipart uploads
+type listMultipartUploadsResult struct {
+	Uploads []MultipartUploadInfo `xml:"Upload"`
+
+	// The next page starts after NextKeyMarker and NextUploadIdMarker
+	IsTruncated        bool   `xml:"IsTruncated"`
+	NextKeyMarker      string `xml:"NextKeyMarker"`
+	NextUploadIDMarker string `xml:"NextUploadIdMarker"`
+}
+
+// ListMultipartUploads lists the incomplete multipart uploads in a bucket
+// under prefix, following every page of the listing. With
+// WithInitiatedBefore only uploads started before that time are returned,
+// e.g. to find and abort abandoned uploads with AbortMultipartUpload.
+func (c *Client) ListMultipartUploads(ctx context.Context, bucketName, prefix string, opts ...RequestOption) ([]MultipartUploadInfo, error) {
+	o := newRequestOptions(opts)
+
+	base := c.bucketURL(bucketName) + "?uploads"
+	if prefix = c.serverKey(prefix, o); prefix != "" {
+		base += "&prefix=" + url.QueryEscape(prefix)
+	}
+
+	var (
+		uploads             []MultipartUploadInfo
+		keyMarker, idMarker string
+	)
+	for {
+		raw := base
+		if keyMarker != "" {
+			raw += "&key-marker=" + url.QueryEscape(keyMarker) + "&upload-id-marker=" + url.QueryEscape(idMarker)
+		}
+
+		var result listMultipartUploadsResult
+		if err := c.getXML(ctx, raw, "list multipart uploads", &result, opts); err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, result.Uploads...)
+		if !result.IsTruncated {
+			break
+		}
+
+		// Servers without next markers expect the last returned upload
+		nextKey, nextID := result.NextKeyMarker, result.NextUploadIDMarker
+		if nextKey == "" && len(result.Uploads) > 0 {
+			last := result.Uploads[len(result.Uploads)-1]
+			nextKey, nextID = last.Key, last.UploadID
+		}
+		if nextKey == "" || nextKey == keyMarker && nextID == idMarker {
+			return nil, fmt.Errorf("failed to list multipart uploads: truncated listing without a next page")
+		}
+		keyMarker, idMarker = nextKey, nextID
+	}
+
+	for i := range uploads {
+		uploads[i].Key = c.relativeKey(uploads[i].Key)
+	}
+	if !o.initiatedBefore.IsZero() {
+		uploads = slices.DeleteFunc(uploads, func(u MultipartUploadInfo) bool {
+			return !u.Initiated.Before(o.initiatedBefore)
+		})
+	}
+
+	return uploads, nil
+}
+
+// url builds the upload's URL with the upload ID and any extra query
+func (u *MultipartUpload) url(query string) string {
+	raw := u.client.objectURL(u.bucketName, u.objectKey, u.keyOptions()) + "?uploadId=" + url.QueryEscape(u.uploadID)
+	if query != "" {
+		raw += "&" + query
+	}
+	return raw
+}
+
+// countingReader counts the bytes read through it. The count may be read
+// while the transport is still reading.
+type countingReader struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
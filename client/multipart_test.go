@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListMultipartUploadsPaginates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch marker := r.URL.Query().Get("key-marker"); marker {
+		case "":
+			fmt.Fprint(w, `<ListMultipartUploadsResult><IsTruncated>true</IsTruncated>
+				<NextKeyMarker>b</NextKeyMarker><NextUploadIdMarker>2</NextUploadIdMarker>
+				<Upload><Key>a</Key><UploadId>1</UploadId></Upload>
+				<Upload><Key>b</Key><UploadId>2</UploadId></Upload></ListMultipartUploadsResult>`)
+		case "b":
+			// No next markers: the client continues after the last upload
+			fmt.Fprint(w, `<ListMultipartUploadsResult><IsTruncated>true</IsTruncated>
+				<Upload><Key>c</Key><UploadId>3</UploadId></Upload></ListMultipartUploadsResult>`)
+		case "c":
+			if r.URL.Query().Get("upload-id-marker") != "3" {
+				http.Error(w, "bad upload id marker", http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `<ListMultipartUploadsResult>
+				<Upload><Key>d</Key><UploadId>4</UploadId></Upload></ListMultipartUploadsResult>`)
+		default:
+			http.Error(w, "unexpected marker "+marker, http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(ClientOptions{BaseURL: srv.URL, APIKey: "test-key"})
+	uploads, err := c.ListMultipartUploads(context.Background(), "bucket", "")
+	if err != nil {
+		t.Fatalf("ListMultipartUploads: %v", err)
+	}
+
+	var ids string
+	for _, u := range uploads {
+		ids += u.UploadID
+	}
+	if ids != "1234" {
+		t.Errorf("listed upload IDs %q, want 1234", ids)
+	}
+}
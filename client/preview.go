@@ -0,0 +1,37 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Default URL templates for PreviewURL and ThumbnailURL.
+// {base} is the client's base URL, {bucket} and {key} are escaped for use in
+// a path, and {size} is the requested thumbnail edge length in pixels.
+const (
+	DefaultPreviewURLTemplate   = "{base}/api/{bucket}/{key}"
+	DefaultThumbnailURLTemplate = "{base}/api/{bucket}/{key}?thumbnail={size}"
+)
+
+// PreviewURL returns the preview URL of an object built from the preview
+// template, independent of what the upload response contained
+func (c *Client) PreviewURL(bucketName, objectKey string) string {
+	return c.expandURLTemplate(c.previewTemplate, bucketName, objectKey, 0)
+}
+
+// ThumbnailURL returns the URL of an object's thumbnail with the given size,
+// built from the thumbnail template
+func (c *Client) ThumbnailURL(bucketName, objectKey string, size int) string {
+	return c.expandURLTemplate(c.thumbnailTemplate, bucketName, objectKey, size)
+}
+
+// expandURLTemplate fills in the placeholders of a URL template
+func (c *Client) expandURLTemplate(template, bucketName, objectKey string, size int) string {
+	return strings.NewReplacer(
+		"{base}", c.baseURLFor(c.bucket(bucketName)),
+		"{bucket}", url.PathEscape(c.bucket(bucketName)),
+		"{key}", escapeKey(c.serverKey(objectKey, nil)),
+		"{size}", strconv.Itoa(size),
+	).Replace(template)
+}
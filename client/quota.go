@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"encoding/xml"
+)
+
+// Quota represents a bucket's usage and limits. A zero maximum means the
+// server enforces no limit.
+type Quota struct {
+	XMLName     xml.Name `xml:"BucketQuota"`
+	UsedBytes   int64    `xml:"UsedBytes"`
+	MaxBytes    int64    `xml:"MaxBytes"`
+	UsedObjects int64    `xml:"UsedObjects"`
+	MaxObjects  int64    `xml:"MaxObjects"`
+}
+
+// RemainingBytes returns how many more bytes fit in the bucket, or -1 when
+// there is no byte limit
+func (q *Quota) RemainingBytes() int64 {
+	if q.MaxBytes <= 0 {
+		return -1
+	}
+	return max(q.MaxBytes-q.UsedBytes, 0)
+}
+
+// RemainingObjects returns how many more objects fit in the bucket, or -1
+// when there is no object limit
+func (q *Quota) RemainingObjects() int64 {
+	if q.MaxObjects <= 0 {
+		return -1
+	}
+	return max(q.MaxObjects-q.UsedObjects, 0)
+}
+
+// GetBucketQuota retrieves the usage and limits of a bucket, e.g. to check
+// there is room before a large upload job
+func (c *Client) GetBucketQuota(ctx context.Context, bucketName string, opts ...RequestOption) (*Quota, error) {
+	url := c.bucketURL(bucketName) + "?quota"
+
+	var quota Quota
+	if err := c.getXML(ctx, url, "get bucket quota", &quota, opts); err != nil {
+		return nil, err
+	}
+
+	return &quota, nil
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// PutObjectRange overwrites the bytes of an existing object starting at
+// offset start with the data read from r, by sending a PUT with a
+// Content-Range header. A server without partial write support fails the
+// call with an error matching ErrPartialWriteUnsupported.
+func (c *Client) PutObjectRange(ctx context.Context, bucketName, objectKey string, r io.Reader, start int64, opts ...RequestOption) error {
+	if start < 0 {
+		return fmt.Errorf("invalid range start %d: must not be negative", start)
+	}
+
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
+
+	// Content-Range needs the end offset, so readers of unknown length are buffered
+	size := readerSize(r)
+	if size < 0 {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("failed to read data: %w", err)
+		}
+		r, size = bytes.NewReader(data), int64(len(data))
+	}
+	if size == 0 {
+		return fmt.Errorf("failed to put object range: no data")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, r)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.ContentLength = size
+	req.Header.Set("Content-Type", ContentTypeOctetStream)
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/*", start, start+size-1))
+	o.apply(req)
+	c.addAuth(req)
+
+	resp, err := c.do(req)
+	c.metadata.invalidate(c.bucket(bucketName), objectKey)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotImplemented, http.StatusMethodNotAllowed:
+		return fmt.Errorf("%w: %w", ErrPartialWriteUnsupported, c.statusError(resp, "put object range"))
+	default:
+		return c.statusError(resp, "put object range")
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// AcceptsRanges reports whether the server serves byte ranges of an object,
+// so callers can fall back to a single full download instead of receiving
+// 200 where 206 was expected. It reads Accept-Ranges from a HEAD and, when
+// the server does not send it, probes with a one-byte ranged GET.
+func (c *Client) AcceptsRanges(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (bool, error) {
+	resp, err := c.probeRanges(ctx, http.MethodHead, bucketName, objectKey, opts)
+	if err != nil {
+		return false, err
+	}
+
+	if values := resp.Header.Values("Accept-Ranges"); len(values) > 0 {
+		for _, value := range values {
+			for unit := range strings.SplitSeq(value, ",") {
+				if strings.EqualFold(strings.TrimSpace(unit), "bytes") {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+
+	resp, err = c.probeRanges(ctx, http.MethodGet, bucketName, objectKey, opts)
+	if err != nil {
+		return false, err
+	}
+	return resp.StatusCode == http.StatusPartialContent, nil
+}
+
+// probeRanges sends a request for the first byte of an object and returns
+// the response with its body already closed
+func (c *Client) probeRanges(ctx context.Context, method, bucketName, objectKey string, opts []RequestOption) (*http.Response, error) {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Range", "bytes=0-0")
+	o.apply(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return nil, c.statusError(resp, "check range support")
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,322 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RequestOption customizes a single API call
+type RequestOption func(*requestOptions)
+
+// requestOptions holds the per-call settings collected from RequestOption values
+type requestOptions struct {
+	ifRange          string
+	allowEmptyPrefix bool
+	errorOnEmpty     bool
+
+	contentMD5Precheck bool
+	idempotencyKey     string
+	createOnly         bool
+	autoCreateBucket   bool
+	streamingChecksum  bool
+
+	formFieldName string
+	formFields    [][2]string
+	boundary      string
+
+	initiatedBefore time.Time
+	partSize        int
+	maxBytes        int64
+
+	acceptEncoding string
+	rawEncoding    bool
+	fallbackKey    string
+	noKeyCaseFold  bool
+	verifyCopy     bool
+
+	query   url.Values
+	headers http.Header
+}
+
+// newRequestOptions applies opts over the zero settings
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithIfRange sends If-Range with a ranged read, so the server returns only the
+// range when the object still has this ETag and the full object when it changed
+func WithIfRange(etag string) RequestOption {
+	return func(o *requestOptions) {
+		o.ifRange = etag
+	}
+}
+
+// WithAllowEmptyPrefix lets prefix-wide operations run with an empty prefix,
+// i.e. against every object in the bucket
+func WithAllowEmptyPrefix() RequestOption {
+	return func(o *requestOptions) {
+		o.allowEmptyPrefix = true
+	}
+}
+
+// WithErrorOnEmpty makes ListObjects fail with ErrNoObjects instead of
+// returning an empty list
+func WithErrorOnEmpty() RequestOption {
+	return func(o *requestOptions) {
+		o.errorOnEmpty = true
+	}
+}
+
+// WithContentMD5Precheck sends the body's MD5 as Content-MD5 together with
+// Expect: 100-continue, so the server can reject a bad request from its
+// headers before the body is transferred and verify the body it receives
+func WithContentMD5Precheck() RequestOption {
+	return func(o *requestOptions) {
+		o.contentMD5Precheck = true
+	}
+}
+
+// WithIdempotencyKey sets the Idempotency-Key header sent with an upload.
+// Without it a random key is generated once per call. The server must store
+// keys it has seen and replay the original response for a repeated key for
+// this to prevent duplicate side effects such as webhooks.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// WithCreateOnly makes an upload succeed only if the object does not exist
+// yet, by sending If-None-Match: *. An existing object fails the upload with
+// an error matching ErrObjectExists, without a racy HEAD-then-PUT.
+func WithCreateOnly() RequestOption {
+	return func(o *requestOptions) {
+		o.createOnly = true
+	}
+}
+
+// WithAutoCreateBucket makes PutObject create a missing bucket and retry the
+// upload once. It is off by default because in production it can hide a
+// misconfigured bucket name, and the failed first attempt costs an extra
+// upload plus a bucket creation round trip.
+func WithAutoCreateBucket(enabled bool) RequestOption {
+	return func(o *requestOptions) {
+		o.autoCreateBucket = enabled
+	}
+}
+
+// WithStreamingChecksum makes PutObjectStream send the SHA-256 of the data
+// for the server to verify, as a trailer when the reader cannot be rewound
+func WithStreamingChecksum() RequestOption {
+	return func(o *requestOptions) {
+		o.streamingChecksum = true
+	}
+}
+
+// WithQueryParam adds a query parameter to the request URL, merged with any
+// parameters the method sets itself. It gives access to server subresources
+// and feature flags this package does not model yet.
+func WithQueryParam(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.query == nil {
+			o.query = url.Values{}
+		}
+		o.query.Add(key, value)
+	}
+}
+
+// WithFormFieldName sets the name of the multipart field that carries the
+// object data on uploads. The default is "file".
+func WithFormFieldName(name string) RequestOption {
+	return func(o *requestOptions) {
+		o.formFieldName = name
+	}
+}
+
+// WithFormField adds a plain form field to the multipart body of an upload,
+// e.g. bucket or key for servers that read them from the form. Fields are
+// written in order before the file part.
+func WithFormField(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.formFields = append(o.formFields, [2]string{key, value})
+	}
+}
+
+// WithInitiatedBefore makes ListMultipartUploads return only uploads started
+// before t
+func WithInitiatedBefore(t time.Time) RequestOption {
+	return func(o *requestOptions) {
+		o.initiatedBefore = t
+	}
+}
+
+// WithPartSize sets the buffer and part size of an ObjectWriter in bytes
+func WithPartSize(size int) RequestOption {
+	return func(o *requestOptions) {
+		o.partSize = size
+	}
+}
+
+// WithMaxBytes sets the largest object GetObjectBytes reads into memory,
+// including manifests read by GetManifestObjects
+func WithMaxBytes(n int64) RequestOption {
+	return func(o *requestOptions) {
+		o.maxBytes = n
+	}
+}
+
+// WithAcceptEncoding sets the Accept-Encoding of GetObject, e.g. "br, gzip"
+// or "identity", instead of the transport's automatic gzip. Responses in
+// gzip or deflate are decoded; other encodings, such as br, fail with
+// ErrUnsupportedEncoding. Combine with WithRawEncoding to receive any
+// encoding as sent.
+func WithAcceptEncoding(encoding string) RequestOption {
+	return func(o *requestOptions) {
+		o.acceptEncoding = encoding
+	}
+}
+
+// WithRawEncoding stops GetObject from decoding a response requested with
+// WithAcceptEncoding. The body is then an *EncodedReader naming the encoding.
+func WithRawEncoding() RequestOption {
+	return func(o *requestOptions) {
+		o.rawEncoding = true
+	}
+}
+
+// WithMultipartBoundary sets the boundary of an upload's multipart body
+// instead of a random one, for servers that verify a signature computed over
+// the exact body. It must be 1 to 70 characters allowed by RFC 2046.
+func WithMultipartBoundary(boundary string) RequestOption {
+	return func(o *requestOptions) {
+		o.boundary = boundary
+	}
+}
+
+// newMultipartWriter returns a multipart writer using the configured boundary
+func (o *requestOptions) newMultipartWriter(w io.Writer) (*multipart.Writer, error) {
+	writer := multipart.NewWriter(w)
+	if o.boundary != "" {
+		if err := writer.SetBoundary(o.boundary); err != nil {
+			return nil, fmt.Errorf("invalid multipart boundary: %w", err)
+		}
+	}
+	return writer, nil
+}
+
+// WithFallbackKey makes GetObject return the object fallbackKey, e.g. a
+// placeholder image, when the requested object does not exist. The reader
+// is then a *FallbackReader reporting whether the fallback was used.
+func WithFallbackKey(fallbackKey string) RequestOption {
+	return func(o *requestOptions) {
+		o.fallbackKey = fallbackKey
+	}
+}
+
+// createFormFile writes the configured form fields and opens the file part
+func (o *requestOptions) createFormFile(writer *multipart.Writer, filename string) (io.Writer, error) {
+	for _, field := range o.formFields {
+		if err := writer.WriteField(field[0], field[1]); err != nil {
+			return nil, fmt.Errorf("failed to write form field %q: %w", field[0], err)
+		}
+	}
+
+	name := o.formFieldName
+	if name == "" {
+		name = "file"
+	}
+	fileWriter, err := writer.CreateFormFile(name, filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %w", err)
+	}
+	return fileWriter, nil
+}
+
+// WithPartNumber makes HeadObject describe a single part of a multipart
+// object: Size is then the size of that part, while PartsCount is still the
+// total number of parts. Parts are numbered from 1.
+func WithPartNumber(partNum int) RequestOption {
+	return WithQueryParam("partNumber", strconv.Itoa(partNum))
+}
+
+// WithStorageClass sets the storage class of an uploaded or copied object,
+// e.g. a cheaper tier for rarely read data; copying an object onto itself
+// with a new class moves it between tiers. HeadObject reports it as
+// ObjectInfo.StorageClass.
+func WithStorageClass(class string) RequestOption {
+	return withHeader("X-Storage-Class", class)
+}
+
+// WithVerifyCopy makes CopyObject compare the size and ETag of the copy with
+// its source, at the cost of two HEAD requests
+func WithVerifyCopy() RequestOption {
+	return func(o *requestOptions) {
+		o.verifyCopy = true
+	}
+}
+
+// WithoutKeyCaseFold sends the key and listing prefix of a call as given,
+// bypassing ClientOptions.KeyCaseFold, e.g. to reach a mixed-case key
+func WithoutKeyCaseFold() RequestOption {
+	return func(o *requestOptions) {
+		o.noKeyCaseFold = true
+	}
+}
+
+// WithIfModifiedSince sends If-Modified-Since, so HeadObject fails with an
+// error matching ErrNotModified when the object has not changed since t
+func WithIfModifiedSince(t time.Time) RequestOption {
+	return withHeader("If-Modified-Since", t.UTC().Format(http.TimeFormat))
+}
+
+// setIdempotencyKey sets the configured or a newly generated idempotency key on req
+func (o *requestOptions) setIdempotencyKey(req *http.Request) {
+	if o.idempotencyKey == "" {
+		o.idempotencyKey = rand.Text()
+	}
+	req.Header.Set("Idempotency-Key", o.idempotencyKey)
+}
+
+// setCreateOnly sets If-None-Match: * when WithCreateOnly is used
+func (o *requestOptions) setCreateOnly(req *http.Request) {
+	if o.createOnly {
+		req.Header.Set("If-None-Match", "*")
+	}
+}
+
+// withHeader sets a request header; used by methods that map arguments to headers
+func withHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.headers == nil {
+			o.headers = http.Header{}
+		}
+		o.headers.Set(key, value)
+	}
+}
+
+// apply adds the per-call settings shared by every method to req
+func (o *requestOptions) apply(req *http.Request) {
+	for key, values := range o.headers {
+		req.Header[key] = values
+	}
+
+	if len(o.query) > 0 {
+		// Appending keeps valueless subresources such as "?cors" intact
+		extra := o.query.Encode()
+		if req.URL.RawQuery == "" {
+			req.URL.RawQuery = extra
+		} else {
+			req.URL.RawQuery += "&" + extra
+		}
+	}
+}
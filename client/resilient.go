@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxResumes bounds how many times a resilient download reconnects in a row
+// without making progress
+const maxResumes = 5
+
+// ResilientGetObject retrieves an object like GetObject, but if the
+// connection drops mid-stream the returned reader transparently re-requests
+// the remaining bytes with a Range request validated by If-Range. If the
+// object changed in between, reading fails with an error matching
+// ErrObjectChanged instead of splicing two versions together.
+func (c *Client) ResilientGetObject(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (io.ReadCloser, error) {
+	resp, err := c.openObjectAt(ctx, bucketName, objectKey, 0, "", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefer the strong ETag; Last-Modified is the fallback validator
+	validator := resp.Header.Get("ETag")
+	if validator == "" {
+		validator = resp.Header.Get("Last-Modified")
+	}
+
+	return &resilientReader{
+		ctx:        ctx,
+		client:     c,
+		bucketName: bucketName,
+		objectKey:  objectKey,
+		opts:       opts,
+		body:       resp.Body,
+		validator:  validator,
+	}, nil
+}
+
+// openObjectAt requests an object from offset on. A non-empty validator is
+// sent as If-Range, so a changed object comes back in full with status 200.
+func (c *Client) openObjectAt(ctx context.Context, bucketName, objectKey string, offset int64, validator string, opts []RequestOption) (*http.Response, error) {
+	o := newRequestOptions(opts)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.objectURL(bucketName, objectKey, o), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if validator != "" {
+			req.Header.Set("If-Range", validator)
+		}
+	}
+	o.apply(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		defer resp.Body.Close()
+		return nil, c.statusError(resp, "get object")
+	}
+
+	return resp, nil
+}
+
+// resilientReader resumes an interrupted download from the current offset
+type resilientReader struct {
+	ctx        context.Context
+	client     *Client
+	bucketName string
+	objectKey  string
+	opts       []RequestOption
+
+	body      io.ReadCloser
+	validator string
+	offset    int64
+	resumes   int
+	err       error
+}
+
+func (r *resilientReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	for {
+		n, err := r.body.Read(p)
+		r.offset += int64(n)
+		if n > 0 {
+			r.resumes = 0
+		}
+
+		if err == nil || err == io.EOF || n > 0 {
+			// Deliver data first; a persistent error is returned by the next Read
+			return n, err
+		}
+		if r.ctx.Err() != nil || r.validator == "" || r.resumes >= maxResumes {
+			return 0, err
+		}
+
+		if resumeErr := r.resume(); resumeErr != nil {
+			r.err = errors.Join(err, resumeErr)
+			return 0, r.err
+		}
+	}
+}
+
+// resume replaces the broken body with one continuing at the current offset
+func (r *resilientReader) resume() error {
+	r.body.Close()
+	r.resumes++
+
+	resp, err := r.client.openObjectAt(r.ctx, r.bucketName, r.objectKey, r.offset, r.validator, r.opts)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusPartialContent || !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", r.offset)) {
+		resp.Body.Close()
+		return fmt.Errorf("failed to resume %s at byte %d: %w", r.objectKey, r.offset, ErrObjectChanged)
+	}
+
+	r.body = resp.Body
+	return nil
+}
+
+func (r *resilientReader) Close() error {
+	if r.err != nil {
+		return nil
+	}
+	return r.body.Close()
+}
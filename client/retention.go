@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"encoding/xml"
+	"time"
+)
+
+// RetentionMode is the object-lock mode of a retention period
+type RetentionMode string
+
+const (
+	// RetentionGovernance can be shortened or removed by privileged users
+	RetentionGovernance RetentionMode = "GOVERNANCE"
+	// RetentionCompliance cannot be shortened or removed by anyone
+	RetentionCompliance RetentionMode = "COMPLIANCE"
+)
+
+// ObjectRetention represents an object's retention settings
+type ObjectRetention struct {
+	XMLName         xml.Name      `xml:"Retention"`
+	Mode            RetentionMode `xml:"Mode"`
+	RetainUntilDate time.Time     `xml:"RetainUntilDate"`
+}
+
+// legalHold is the request and response body of the legal-hold subresource
+type legalHold struct {
+	XMLName xml.Name `xml:"LegalHold"`
+	Status  string   `xml:"Status"`
+}
+
+// SetObjectRetention locks an object against deletion and overwrite until the given time
+func (c *Client) SetObjectRetention(ctx context.Context, bucketName, objectKey string, mode RetentionMode, until time.Time, opts ...RequestOption) error {
+	url := c.objectURL(bucketName, objectKey, newRequestOptions(opts)) + "?retention"
+
+	retention := &ObjectRetention{Mode: mode, RetainUntilDate: until.UTC()}
+	return c.putXML(ctx, url, "set object retention", retention, opts)
+}
+
+// GetObjectRetention retrieves an object's retention settings
+func (c *Client) GetObjectRetention(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (*ObjectRetention, error) {
+	url := c.objectURL(bucketName, objectKey, newRequestOptions(opts)) + "?retention"
+
+	var retention ObjectRetention
+	if err := c.getXML(ctx, url, "get object retention", &retention, opts); err != nil {
+		return nil, err
+	}
+
+	return &retention, nil
+}
+
+// SetObjectLegalHold turns an object's legal hold on or off.
+// A held object cannot be deleted regardless of its retention period.
+func (c *Client) SetObjectLegalHold(ctx context.Context, bucketName, objectKey string, on bool, opts ...RequestOption) error {
+	url := c.objectURL(bucketName, objectKey, newRequestOptions(opts)) + "?legal-hold"
+
+	hold := &legalHold{Status: "OFF"}
+	if on {
+		hold.Status = "ON"
+	}
+	return c.putXML(ctx, url, "set object legal hold", hold, opts)
+}
+
+// GetObjectLegalHold reports whether an object is under legal hold
+func (c *Client) GetObjectLegalHold(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (bool, error) {
+	url := c.objectURL(bucketName, objectKey, newRequestOptions(opts)) + "?legal-hold"
+
+	var hold legalHold
+	if err := c.getXML(ctx, url, "get object legal hold", &hold, opts); err != nil {
+		return false, err
+	}
+
+	return hold.Status == "ON", nil
+}
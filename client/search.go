@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// MetadataQuery selects objects by server-indexed metadata. Zero fields do
+// not constrain the search; all set fields must match.
+type MetadataQuery struct {
+	// Metadata holds user metadata keys that must equal the given values
+	Metadata map[string]string
+	// MinSize and MaxSize bound the object size in bytes (inclusive)
+	MinSize int64
+	MaxSize int64
+	// ContentTypePrefix matches content types such as "image/"
+	ContentTypePrefix string
+}
+
+// encode serializes the query into search endpoint parameters
+func (q MetadataQuery) encode() url.Values {
+	params := url.Values{}
+	for key, value := range q.Metadata {
+		params.Set("meta."+key, value)
+	}
+	if q.MinSize > 0 {
+		params.Set("min-size", strconv.FormatInt(q.MinSize, 10))
+	}
+	if q.MaxSize > 0 {
+		params.Set("max-size", strconv.FormatInt(q.MaxSize, 10))
+	}
+	if q.ContentTypePrefix != "" {
+		params.Set("content-type-prefix", q.ContentTypePrefix)
+	}
+	return params
+}
+
+// SearchObjects finds objects matching query with the server's metadata
+// index, e.g. all images over 1MB tagged project=x, without listing the
+// whole bucket
+func (c *Client) SearchObjects(ctx context.Context, bucketName string, query MetadataQuery, opts ...RequestOption) ([]ObjectInfo, error) {
+	raw := c.bucketURL(bucketName) + "?search"
+	if params := query.encode(); len(params) > 0 {
+		raw += "&" + params.Encode()
+	}
+
+	var result ListBucketResult
+	if err := c.getXML(ctx, raw, "search objects", &result, opts); err != nil {
+		return nil, err
+	}
+
+	for i := range result.Contents {
+		result.Contents[i].Key = c.relativeKey(result.Contents[i].Key)
+	}
+	return result.Contents, nil
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// requestLimiter bounds the number of in-flight requests across goroutines.
+// A nil limiter does not limit.
+type requestLimiter chan struct{}
+
+// newRequestLimiter returns a limiter for n concurrent requests, or nil when
+// n is not positive
+func newRequestLimiter(n int) requestLimiter {
+	if n <= 0 {
+		return nil
+	}
+	return make(requestLimiter, n)
+}
+
+// acquire waits for a free slot or until ctx is done
+func (l requestLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to acquire request slot: %w", ctx.Err())
+	}
+}
+
+// release frees a slot taken by acquire
+func (l requestLimiter) release() {
+	if l != nil {
+		<-l
+	}
+}
+
+// limitedBody releases the request slot once the response body is closed,
+// so a streamed download counts as in flight until the caller is done
+type limitedBody struct {
+	io.ReadCloser
+	once    sync.Once
+	limiter requestLimiter
+}
+
+func (b *limitedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.limiter.release)
+	return err
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// serveRequestHeaders are the client request headers ServeObject forwards
+var serveRequestHeaders = []string{"Range", "If-Range", "If-None-Match", "If-Modified-Since"}
+
+// serveResponseHeaders are the object response headers ServeObject copies
+var serveResponseHeaders = []string{
+	"Content-Type", "Content-Length", "Content-Range", "Content-Encoding",
+	"Content-Disposition", "Cache-Control", "ETag", "Last-Modified", "Accept-Ranges",
+}
+
+// ServeObject writes an object to w in response to r, making the client a
+// drop-in proxy. The Range, If-Range, If-None-Match and If-Modified-Since
+// headers of r are forwarded, so range requests get 206 and cached copies
+// get 304. Other failures, e.g. a missing object, are returned without
+// writing to w so the handler can choose the response.
+func (c *Client) ServeObject(ctx context.Context, w http.ResponseWriter, r *http.Request, bucketName, objectKey string, opts ...RequestOption) error {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
+
+	method := http.MethodGet
+	if r.Method == http.MethodHead {
+		method = http.MethodHead
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for _, key := range serveRequestHeaders {
+		if value := r.Header.Get(key); value != "" {
+			req.Header.Set(key, value)
+		}
+	}
+
+	o.apply(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent, http.StatusNotModified, http.StatusRequestedRangeNotSatisfiable:
+	default:
+		return c.statusError(resp, "get object")
+	}
+
+	for _, key := range serveResponseHeaders {
+		if values := resp.Header.Values(key); len(values) > 0 {
+			w.Header()[key] = values
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+
+	if method == http.MethodHead || resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if _, err := c.buffers.copyBuffer(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to copy object data: %w", err)
+	}
+	return nil
+}
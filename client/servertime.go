@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ServerTime returns the server's current time from the Date header of a
+// cheap HEAD request to the server of the default bucket, as chosen by
+// BaseURLResolver. With ClockSkewCorrection enabled, the observed offset
+// from the local clock is remembered for ServerNow.
+func (c *Client) ServerTime(ctx context.Context, opts ...RequestOption) (time.Time, error) {
+	o := newRequestOptions(opts)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.baseURLFor(c.bucket(""))+"/api/", nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	o.apply(req)
+	c.addAuth(req)
+
+	sent := c.clock.Now()
+	resp, err := c.do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	received := c.clock.Now()
+
+	// Any status will do as long as the server dated the response
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read server time: missing or invalid Date header (status: %d)", resp.StatusCode)
+	}
+
+	if c.skewCorrection {
+		// Compare against the midpoint of the round trip; Date has one-second resolution
+		local := sent.Add(received.Sub(sent) / 2)
+		c.skew.Store(int64(serverTime.Sub(local)))
+	}
+
+	return serverTime, nil
+}
+
+// ClockSkew returns the last observed offset of the server clock from the
+// local clock, or 0 if ClockSkewCorrection is off or ServerTime was not called
+func (c *Client) ClockSkew() time.Duration {
+	return time.Duration(c.skew.Load())
+}
+
+// ServerNow returns the local time adjusted by the observed clock skew. Use
+// it for timestamps the server validates, such as expiry times.
+func (c *Client) ServerNow() time.Time {
+	return c.clock.Now().Add(c.ClockSkew())
+}
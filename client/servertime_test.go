@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServerTimeUsesResolver(t *testing.T) {
+	var hits int
+	regional := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer regional.Close()
+
+	c := NewClient(ClientOptions{
+		BaseURL:       "http://127.0.0.1:1",
+		DefaultBucket: "eu-data",
+		BaseURLResolver: func(bucket string) string {
+			if bucket == "eu-data" {
+				return regional.URL
+			}
+			return ""
+		},
+	})
+
+	if _, err := c.ServerTime(context.Background()); err != nil {
+		t.Fatalf("ServerTime: %v", err)
+	}
+	if hits != 1 {
+		t.Errorf("regional server saw %d requests, want 1", hits)
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// SortField selects the field ListObjectsSorted orders by
+type SortField int
+
+// Fields ListObjectsSorted can order by
+const (
+	SortByKey SortField = iota
+	SortBySize
+	SortByLastModified
+)
+
+// ListObjectsSorted lists the objects under prefix and sorts them by the
+// given field, descending when desc is set; ties are ordered by key. The
+// full listing is fetched, following every page, and sorted client-side in
+// O(n log n).
+func (c *Client) ListObjectsSorted(ctx context.Context, bucketName, prefix string, by SortField, desc bool, opts ...RequestOption) ([]ObjectInfo, error) {
+	result, err := c.listAllObjects(ctx, bucketName, prefix, "", opts)
+	if err != nil {
+		return nil, err
+	}
+	objects := result.Contents
+	if len(objects) == 0 && newRequestOptions(opts).errorOnEmpty {
+		return nil, fmt.Errorf("%w under prefix %q", ErrNoObjects, prefix)
+	}
+
+	slices.SortFunc(objects, func(a, b ObjectInfo) int {
+		var order int
+		switch by {
+		case SortBySize:
+			order = cmp.Compare(a.Size, b.Size)
+		case SortByLastModified:
+			order = a.LastModified.Compare(b.LastModified)
+		}
+		if order == 0 {
+			order = cmp.Compare(a.Key, b.Key)
+		}
+		if desc {
+			return -order
+		}
+		return order
+	})
+
+	return objects, nil
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestListObjectsSortedAllPages(t *testing.T) {
+	c, fake := newTestClient(t)
+	fake.pageSize = 2
+	ctx := context.Background()
+
+	for key, data := range map[string]string{"a": "xxx", "b": "x", "c": "xxxxx", "d": "xx", "e": "xxxx"} {
+		if _, err := c.PutObject(ctx, "bucket", key, strings.NewReader(data), ""); err != nil {
+			t.Fatalf("PutObject %s: %v", key, err)
+		}
+	}
+
+	objects, err := c.ListObjectsSorted(ctx, "bucket", "", SortBySize, true)
+	if err != nil {
+		t.Fatalf("ListObjectsSorted: %v", err)
+	}
+
+	var got []string
+	for _, obj := range objects {
+		got = append(got, obj.Key)
+	}
+	if strings.Join(got, ",") != "c,e,a,d,b" {
+		t.Errorf("ListObjectsSorted = %v, want [c e a d b]", got)
+	}
+}
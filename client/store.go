@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+	"io"
+)
+
+// ObjectStore is the set of core bucket and object operations of Client.
+// Code that depends on ObjectStore instead of *Client can substitute a fake
+// in tests.
+type ObjectStore interface {
+	MakeBucket(ctx context.Context, bucketName string, opts ...RequestOption) error
+	DeleteBucket(ctx context.Context, bucketName string, opts ...RequestOption) error
+
+	PutObject(ctx context.Context, bucketName, objectKey string, reader io.Reader, filename string, opts ...RequestOption) (*UploadResult, error)
+	PutObjectStream(ctx context.Context, bucketName, objectKey string, reader io.Reader, filename string, opts ...RequestOption) (*UploadResult, error)
+	GetObject(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (io.ReadCloser, error)
+	GetObjectRange(ctx context.Context, bucketName, objectKey string, start, end int64, opts ...RequestOption) (io.ReadCloser, error)
+	HeadObject(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (*ObjectInfo, error)
+	ObjectExists(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (bool, error)
+	DeleteObject(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) error
+	ListObjects(ctx context.Context, bucketName string, prefix string, opts ...RequestOption) ([]ObjectInfo, error)
+}
+
+var _ ObjectStore = (*Client)(nil)
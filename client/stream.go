@@ -0,0 +1,143 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"path"
+)
+
+// PutObjectStream uploads an object of unknown length without buffering it.
+// The multipart body is produced incrementally and sent with chunked transfer
+// encoding (no Content-Length), so reader can be e.g. a command's stdout.
+// The server must accept chunked request bodies.
+//
+// With WithStreamingChecksum the SHA-256 of the data is sent as the
+// X-Checksum-Sha256 trailer, computed while the body streams. A reader that
+// can seek is hashed up front instead and the checksum sent as a header,
+// for servers that do not read trailers.
+func (c *Client) PutObjectStream(ctx context.Context, bucketName, objectKey string, reader io.Reader, filename string, opts ...RequestOption) (result *UploadResult, err error) {
+	o := newRequestOptions(opts)
+	url := c.objectURL(bucketName, objectKey, o)
+
+	c.transferStart(OpPutObjectStream, bucketName, objectKey, readerSize(reader))
+	defer func() { c.uploadEnd(OpPutObjectStream, bucketName, objectKey, result, err) }()
+
+	if reader == nil {
+		reader = http.NoBody
+	}
+	if filename == "" {
+		filename = path.Base(objectKey)
+	}
+
+	pr, pw := io.Pipe()
+	writer, err := o.newMultipartWriter(pw)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &countingReader{r: pr}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, io.NopCloser(counter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// An unknown length makes the transport use chunked encoding
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var checksum hash.Hash
+	if o.streamingChecksum {
+		if seeker, ok := reader.(io.ReadSeeker); ok {
+			sum, err := precomputeChecksum(seeker)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set(checksumHeader, sum)
+		} else {
+			checksum = sha256.New()
+			reader = io.TeeReader(reader, checksum)
+			// The trailer must be declared before the body is sent
+			req.Trailer = http.Header{checksumHeader: nil}
+		}
+	}
+	o.setIdempotencyKey(req)
+	o.setCreateOnly(req)
+	o.apply(req)
+	c.addAuth(req)
+
+	done := make(chan int64, 1)
+	go func() {
+		var written int64
+		err := func() error {
+			fileWriter, err := o.createFormFile(writer, filename)
+			if err != nil {
+				return err
+			}
+			if written, err = c.buffers.copyBuffer(fileWriter, reader); err != nil {
+				return fmt.Errorf("failed to copy file data: %w", err)
+			}
+			if err := writer.Close(); err != nil {
+				return err
+			}
+			// The transport reads trailers once the body is at EOF, after this
+			if checksum != nil {
+				req.Trailer.Set(checksumHeader, base64.StdEncoding.EncodeToString(checksum.Sum(nil)))
+			}
+			return nil
+		}()
+		pw.CloseWithError(err)
+		done <- written
+	}()
+
+	resp, err := c.do(req)
+	c.metadata.invalidate(c.bucket(bucketName), objectKey)
+
+	// Unblock the writer if the server stopped reading early
+	pr.Close()
+	written := <-done
+
+	if err != nil {
+		return nil, uploadError(err, counter.n.Load())
+	}
+	defer resp.Body.Close()
+
+	return c.parseUploadResponse(resp, objectKey, written, o)
+}
+
+// checksumHeader carries the base64 SHA-256 of uploaded data
+const checksumHeader = "X-Checksum-Sha256"
+
+// precomputeChecksum hashes the rest of r and seeks back to where it started
+func precomputeChecksum(r io.ReadSeeker) (string, error) {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute checksum: %w", err)
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", fmt.Errorf("failed to compute checksum: %w", err)
+	}
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to compute checksum: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+// NewLineScanner streams an object and returns a scanner over its lines.
+// Always call Close on the returned closer, even when scanning stops early,
+// to release the underlying connection.
+func (c *Client) NewLineScanner(ctx context.Context, bucketName, objectKey string, opts ...RequestOption) (*bufio.Scanner, io.Closer, error) {
+	body, err := c.GetObject(ctx, bucketName, objectKey, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bufio.NewScanner(body), body, nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+// getXML fetches an XML document such as a bucket or object subresource and decodes it into v
+func (c *Client) getXML(ctx context.Context, url, op string, v any, opts []RequestOption) error {
+	o := newRequestOptions(opts)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	o.apply(req)
+	c.addAuth(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.statusError(resp, op)
+	}
+
+	if err := xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
+
+// putXML sends v encoded as XML, accepting 200 or 204 as success
+func (c *Client) putXML(ctx context.Context, url, op string, v any, opts []RequestOption) error {
+	o := newRequestOptions(opts)
+
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/xml")
+	o.apply(req)
+	c.addAuth(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return c.statusError(resp, op)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// DownloadTar streams the objects in keys into w as a tar archive, one entry
+// per object named after its key, without staging the archive. It stops at
+// the first error, including a canceled ctx. Objects the server sends
+// without a Content-Length are spooled to a temporary file first, as tar
+// headers need the size up front.
+func (c *Client) DownloadTar(ctx context.Context, bucketName string, keys []string, w io.Writer, opts ...RequestOption) error {
+	tw := tar.NewWriter(w)
+
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := c.writeTarEntry(ctx, tw, bucketName, key, opts); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// writeTarEntry downloads one object into the archive
+func (c *Client) writeTarEntry(ctx context.Context, tw *tar.Writer, bucketName, key string, opts []RequestOption) error {
+	body, err := c.GetObjectRangeWithInfo(ctx, bucketName, key, 0, 0, opts...)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	var src io.Reader = body
+	size := body.Size
+	if size < 0 {
+		spool, err := os.CreateTemp("", "gtm-tar-*")
+		if err != nil {
+			return fmt.Errorf("failed to create temp file: %w", err)
+		}
+		defer os.Remove(spool.Name())
+		defer spool.Close()
+
+		if size, err = c.buffers.copyBuffer(spool, body); err != nil {
+			return fmt.Errorf("failed to download object: %w", err)
+		}
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to rewind temp file: %w", err)
+		}
+		src = spool
+	}
+
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     key,
+		Size:     size,
+		Mode:     0o644,
+		ModTime:  c.clock.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write archive header: %w", err)
+	}
+	if _, err := c.buffers.copyBuffer(tw, src); err != nil {
+		return fmt.Errorf("failed to write archive entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// RequestTiming is the phase breakdown of one request reported to the
+// HTTPTrace callback. Phases that did not happen, e.g. DNS and connect on a
+// reused connection, are zero.
+type RequestTiming struct {
+	Method string
+	// URL is sanitized the same way as in request logs
+	URL string
+
+	DNS             time.Duration
+	Connect         time.Duration
+	TLSHandshake    time.Duration
+	TimeToFirstByte time.Duration
+	Total           time.Duration
+	ReusedConn      bool
+}
+
+// requestTracer collects phase timestamps from httptrace callbacks, which
+// may run on transport goroutines
+type requestTracer struct {
+	clock Clock
+
+	mu        sync.Mutex
+	start     time.Time
+	dnsStart  time.Time
+	connStart time.Time
+	tlsStart  time.Time
+	timing    RequestTiming
+}
+
+// traceRequest attaches a tracer to req and returns the traced request
+func (c *Client) traceRequest(req *http.Request) (*http.Request, *requestTracer) {
+	t := &requestTracer{clock: c.clock, start: c.clock.Now()}
+	t.timing.Method = req.Method
+	t.timing.URL = sanitizeURL(req.URL)
+
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			t.dnsStart = t.clock.Now()
+			t.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			t.timing.DNS = t.clock.Now().Sub(t.dnsStart)
+			t.mu.Unlock()
+		},
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			t.connStart = t.clock.Now()
+			t.mu.Unlock()
+		},
+		ConnectDone: func(string, string, error) {
+			t.mu.Lock()
+			t.timing.Connect = t.clock.Now().Sub(t.connStart)
+			t.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			t.tlsStart = t.clock.Now()
+			t.mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			t.timing.TLSHandshake = t.clock.Now().Sub(t.tlsStart)
+			t.mu.Unlock()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			t.timing.ReusedConn = info.Reused
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			t.timing.TimeToFirstByte = t.clock.Now().Sub(t.start)
+			t.mu.Unlock()
+		},
+	}
+
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), t
+}
+
+// finish completes the timing once the response headers arrived or the request failed
+func (t *requestTracer) finish() RequestTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.timing.Total = t.clock.Now().Sub(t.start)
+	return t.timing
+}
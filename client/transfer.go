@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+)
+
+// TransferManager owns a concurrency budget and copy buffer pool shared by
+// the bulk operations of one or more clients, such as DeleteObjectsByPrefix,
+// ListObjectsMulti and FetchManifestObjects. Each operation still runs up to
+// its own concurrency, but together they never exceed the manager's budget.
+type TransferManager struct {
+	slots   requestLimiter
+	buffers *bufferPool
+}
+
+// NewTransferManager creates a manager allowing concurrency transfers at once
+// (unlimited when <= 0) that share copy buffers of bufferSize bytes (default
+// 32KB)
+func NewTransferManager(concurrency, bufferSize int) *TransferManager {
+	return &TransferManager{
+		slots:   newRequestLimiter(concurrency),
+		buffers: newBufferPool(bufferSize),
+	}
+}
+
+// forEachTransfer is forEachKey for bulk transfers; with a TransferManager
+// each call of fn holds one of the manager's slots
+func (c *Client) forEachTransfer(ctx context.Context, keys []string, concurrency int, fn func(key string) error) error {
+	if c.transfers == nil {
+		return forEachKey(ctx, keys, concurrency, fn)
+	}
+
+	return forEachKey(ctx, keys, concurrency, func(key string) error {
+		if err := c.transfers.slots.acquire(ctx); err != nil {
+			return err
+		}
+		defer c.transfers.slots.release()
+		return fn(key)
+	})
+}
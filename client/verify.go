@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// VerifyReport lists the differences between a local directory and the
+// objects under a prefix. Keys are relative to the prefix and sorted.
+type VerifyReport struct {
+	// Verified is the number of files that match their object
+	Verified int
+	// Missing holds local files with no object
+	Missing []string
+	// Extra holds objects with no local file
+	Extra []string
+	// Mismatched holds files whose size or ETag differ from their object
+	Mismatched []string
+}
+
+// OK reports whether every file matches an object and there are no extras
+func (r *VerifyReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Mismatched) == 0
+}
+
+// localFile is a hashed file of a verified directory
+type localFile struct {
+	size      int64
+	etag      string
+	multipart string
+}
+
+// VerifyBucket checks that the objects under keyPrefix match the files in
+// localDir, e.g. after a sync or migration. The listing, following every
+// page, runs while local files are hashed in parallel. Files are compared by size and ETag; objects
+// uploaded in parts only match by ETag when the part size is given with
+// WithPartSize, and are compared by size otherwise.
+func (c *Client) VerifyBucket(ctx context.Context, bucketName, localDir, keyPrefix string, opts ...RequestOption) (*VerifyReport, error) {
+	o := newRequestOptions(opts)
+
+	var (
+		wg         sync.WaitGroup
+		remote     *ListBucketResult
+		listErr    error
+		listPrefix = strings.Trim(toSlash(keyPrefix), "/")
+	)
+	if listPrefix != "" {
+		listPrefix += "/"
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		remote, listErr = c.listAllObjects(ctx, bucketName, listPrefix, "", opts)
+	}()
+
+	var paths []string
+	walkErr := filepath.WalkDir(localDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if walkErr != nil {
+		wg.Wait()
+		return nil, fmt.Errorf("failed to walk %s: %w", localDir, walkErr)
+	}
+
+	var mu sync.Mutex
+	local := make(map[string]localFile, len(paths))
+	hashErr := forEachKey(ctx, paths, runtime.NumCPU(), func(path string) error {
+		rel, err := filepath.Rel(localDir, path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+		file, err := hashLocalFile(path, int64(o.partSize))
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		local[filepath.ToSlash(rel)] = file
+		mu.Unlock()
+		return nil
+	})
+
+	wg.Wait()
+	if listErr != nil {
+		return nil, listErr
+	}
+	if hashErr != nil {
+		return nil, hashErr
+	}
+
+	report := &VerifyReport{}
+	for _, obj := range remote.Contents {
+		key := strings.TrimPrefix(obj.Key, listPrefix)
+
+		file, ok := local[key]
+		if !ok {
+			report.Extra = append(report.Extra, key)
+			continue
+		}
+		delete(local, key)
+
+		if file.matches(obj) {
+			report.Verified++
+		} else {
+			report.Mismatched = append(report.Mismatched, key)
+		}
+	}
+	for key := range local {
+		report.Missing = append(report.Missing, key)
+	}
+
+	slices.Sort(report.Missing)
+	slices.Sort(report.Extra)
+	slices.Sort(report.Mismatched)
+
+	return report, nil
+}
+
+// hashLocalFile computes the size and ETags of a file, including the
+// multipart ETag when partSize is positive
+func hashLocalFile(path string, partSize int64) (localFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return localFile{}, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return localFile{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	file := localFile{size: info.Size()}
+	if file.etag, err = ComputeETag(f, 0); err != nil {
+		return localFile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if partSize > 0 {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return localFile{}, fmt.Errorf("failed to rewind file: %w", err)
+		}
+		if file.multipart, err = ComputeETag(f, partSize); err != nil {
+			return localFile{}, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return file, nil
+}
+
+// matches compares a file with its object by size and, where it can be
+// reproduced locally, ETag
+func (f localFile) matches(obj ObjectInfo) bool {
+	if f.size != obj.Size {
+		return false
+	}
+
+	etag := strings.Trim(obj.ETag, "\"")
+	switch {
+	case etag == "":
+		return true
+	case strings.Contains(etag, "-"):
+		return f.multipart == "" || f.multipart == etag
+	default:
+		return f.etag == etag
+	}
+}
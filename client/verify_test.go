@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerifyBucketTruncatedListing(t *testing.T) {
+	c, fake := newTestClient(t)
+	fake.pageSize = 2
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.txt":     "alpha",
+		"b.txt":     "bravo",
+		"c.txt":     "charlie",
+		"sub/d.txt": "delta",
+		"sub/e.txt": "echo",
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.PutObject(ctx, "bucket", "backup/"+name, strings.NewReader(data), ""); err != nil {
+			t.Fatalf("PutObject %s: %v", name, err)
+		}
+	}
+
+	report, err := c.VerifyBucket(ctx, "bucket", dir, "backup")
+	if err != nil {
+		t.Fatalf("VerifyBucket: %v", err)
+	}
+	if !report.OK() || report.Verified != len(files) {
+		t.Errorf("VerifyBucket = %+v, want all %d files verified", report, len(files))
+	}
+}
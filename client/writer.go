@@ -0,0 +1,141 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// defaultWriterPartSize is the buffer size of an ObjectWriter unless set with WithPartSize
+const defaultWriterPartSize = 8 << 20
+
+// ObjectWriter builds an object from incremental writes. Data is buffered up
+// to the part size; an object that fits in one buffer is uploaded with
+// PutObject on Close, larger ones are streamed as a multipart upload, one
+// part per full buffer. An ObjectWriter is not safe for concurrent use.
+type ObjectWriter struct {
+	ctx        context.Context
+	client     *Client
+	bucketName string
+	objectKey  string
+	filename   string
+	opts       []RequestOption
+
+	partSize int
+	buf      bytes.Buffer
+	upload   *MultipartUpload
+	nextPart int
+
+	result *UploadResult
+	err    error
+	closed bool
+}
+
+// NewObjectWriter returns a writer that uploads everything written to it as
+// objectKey, finalizing the upload on Close. Use WithPartSize to change the
+// buffer size (default 8MB).
+func (c *Client) NewObjectWriter(ctx context.Context, bucketName, objectKey, filename string, opts ...RequestOption) (*ObjectWriter, error) {
+	o := newRequestOptions(opts)
+
+	partSize := o.partSize
+	if partSize <= 0 {
+		partSize = defaultWriterPartSize
+	}
+
+	return &ObjectWriter{
+		ctx:        ctx,
+		client:     c,
+		bucketName: bucketName,
+		objectKey:  objectKey,
+		filename:   filename,
+		opts:       opts,
+		partSize:   partSize,
+		nextPart:   1,
+	}, nil
+}
+
+// Write buffers p, uploading a part each time the buffer fills up
+func (w *ObjectWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("write to closed object writer")
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), w.partSize-w.buf.Len())
+		w.buf.Write(p[:n])
+		written += n
+		p = p[n:]
+
+		if w.buf.Len() == w.partSize {
+			if err := w.flushPart(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// flushPart uploads the buffer as the next part, starting the multipart
+// upload if needed
+func (w *ObjectWriter) flushPart() error {
+	if w.upload == nil {
+		upload, err := w.client.NewMultipartUpload(w.ctx, w.bucketName, w.objectKey, w.opts...)
+		if err != nil {
+			w.err = err
+			return err
+		}
+		w.upload = upload
+	}
+
+	if err := w.upload.UploadPart(w.ctx, w.nextPart, bytes.NewReader(w.buf.Bytes())); err != nil {
+		w.err = err
+		return err
+	}
+	w.nextPart++
+	w.buf.Reset()
+	return nil
+}
+
+// Close uploads the remaining data and finalizes the object. After a failed
+// write or completion the multipart upload is aborted.
+func (w *ObjectWriter) Close() error {
+	if w.closed {
+		return w.err
+	}
+	w.closed = true
+
+	if w.err == nil {
+		w.result, w.err = w.finish()
+	}
+	if w.err != nil && w.upload != nil {
+		// Use a fresh context so a canceled ctx does not leave parts behind
+		if abortErr := w.upload.Abort(context.WithoutCancel(w.ctx)); abortErr != nil {
+			w.err = errors.Join(w.err, fmt.Errorf("failed to abort multipart upload: %w", abortErr))
+		}
+	}
+	return w.err
+}
+
+// finish sends the buffered data as a single upload or as the last part
+func (w *ObjectWriter) finish() (*UploadResult, error) {
+	if w.upload == nil {
+		return w.client.PutObject(w.ctx, w.bucketName, w.objectKey, bytes.NewReader(w.buf.Bytes()), w.filename, w.opts...)
+	}
+
+	if w.buf.Len() > 0 {
+		if err := w.flushPart(); err != nil {
+			return nil, err
+		}
+	}
+	return w.upload.Complete(w.ctx)
+}
+
+// Result returns the upload result once Close has succeeded, and nil otherwise
+func (w *ObjectWriter) Result() *UploadResult {
+	return w.result
+}